@@ -0,0 +1,143 @@
+package gopixfmts
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PixFmtCandidate is a candidate destination format ranked by
+// FindBestPixFmtOfList.
+type PixFmtCandidate struct {
+	Format PixelFormat
+	// Loss holds the FF_LOSS_* bits of converting the source to Format.
+	Loss Loss
+	// Score is libavutil's internal ranking score, higher being better,
+	// leaving out the bits waived by LossWeights. Negative scores mark
+	// unusable pairs such as hardware formats.
+	Score int
+}
+
+// LossWeights assigns a cost to each FF_LOSS_* bit for
+// FindBestPixFmtOfListWeighted. Bits without an entry cost nothing but
+// still count against a candidate through libavutil's score. Bits with a
+// zero or negative weight are waived: they are left out of the score too, so
+// the ranking ignores them entirely.
+type LossWeights map[Loss]int
+
+// cost returns the summed positive weights of the bits in loss.
+func (w LossWeights) cost(loss Loss) int {
+	total := 0
+	for bit, weight := range w {
+		if loss&bit != 0 && weight > 0 {
+			total += weight
+		}
+	}
+	return total
+}
+
+// waived returns the bits with a zero or negative weight.
+func (w LossWeights) waived() Loss {
+	var bits Loss
+	for bit, weight := range w {
+		if weight <= 0 {
+			bits |= bit
+		}
+	}
+	return bits
+}
+
+// FindBestPixFmtOfList returns the best of candidates for converting src,
+// following avcodec_find_best_pix_fmt_of_list, along with its loss and
+// every candidate ranked from best to worst.
+//
+// The ranking applies av_find_best_pix_fmt_of_2 across the whole list, so
+// the winner is the one repeated FindBestPixFmtOf2 calls would pick. Ties
+// keep the candidates' order. An empty list returns ErrInvalidArgument and
+// unknown formats return ErrUnknownPixelFormat. When no candidate is usable,
+// such as a list of hardware formats, ErrNoSuitableFormat is returned along
+// with the ranking.
+//
+// libavutil does not export its scores, so they are always computed from
+// the generated descriptor table, even in cgo builds. Formats added to
+// libavutil after the table was generated return ErrUnknownPixelFormat,
+// although PixFmtDescGet and FindBestPixFmtOf2 accept them; regenerate the
+// table after updating libavutil.
+func FindBestPixFmtOfList(candidates []PixelFormat, src PixelFormat,
+	hasAlpha bool) (best PixelFormat, loss Loss, ranked []PixFmtCandidate,
+	err error) {
+	return findBestPixFmtOfList(candidates, src, hasAlpha, nil)
+}
+
+// FindBestPixFmtOfListWeighted is FindBestPixFmtOfList with candidates
+// ranked first by the summed weights of their FF_LOSS_* bits, lowest
+// first, and only then by libavutil's score. Weights can forbid a loss,
+// such as FF_LOSS_DEPTH, by making it expensive or tolerate one, such as
+// FF_LOSS_CHROMA, by giving it a weight of 0. The reported Loss of every
+// candidate still includes waived bits. Unusable candidates always rank
+// last.
+func FindBestPixFmtOfListWeighted(candidates []PixelFormat, src PixelFormat,
+	hasAlpha bool, weights LossWeights) (best PixelFormat, loss Loss,
+	ranked []PixFmtCandidate, err error) {
+	if weights == nil {
+		weights = LossWeights{}
+	}
+	return findBestPixFmtOfList(candidates, src, hasAlpha, weights)
+}
+
+func findBestPixFmtOfList(candidates []PixelFormat, src PixelFormat,
+	hasAlpha bool, weights LossWeights) (PixelFormat, Loss,
+	[]PixFmtCandidate, error) {
+	if len(candidates) == 0 {
+		return PixFmtNone, 0, nil, ErrInvalidArgument
+	}
+	if goPixFmtDescGet(src) == nil {
+		return PixFmtNone, 0, nil, ErrUnknownPixelFormat
+	}
+	mask := lossMask(hasAlpha)
+	waived := int(weights.waived())
+	ranked := make([]PixFmtCandidate, len(candidates))
+	for i, pf := range candidates {
+		if goPixFmtDescGet(pf) == nil {
+			return PixFmtNone, 0, nil, fmt.Errorf("%w: candidate %d",
+				ErrUnknownPixelFormat, int(pf))
+		}
+		score, loss := goPixFmtScore(pf, src, mask)
+		if waived != 0 {
+			score, _ = goPixFmtScore(pf, src, mask&^waived)
+		}
+		ranked[i] = PixFmtCandidate{Format: pf, Loss: Loss(loss),
+			Score: score}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if (a.Score < 0) != (b.Score < 0) {
+			return a.Score >= 0
+		}
+		if weights != nil {
+			if ca, cb := weights.cost(a.Loss), weights.cost(b.Loss); ca != cb {
+				return ca < cb
+			}
+		}
+		return betterPixFmt(a, b)
+	})
+	if ranked[0].Score < 0 {
+		return PixFmtNone, 0, ranked, ErrNoSuitableFormat
+	}
+	return ranked[0].Format, ranked[0].Loss, ranked, nil
+}
+
+// betterPixFmt reports whether a beats b by the rules of
+// av_find_best_pix_fmt_of_2: higher score, then fewer padded bits per
+// pixel, then fewer components.
+func betterPixFmt(a, b PixFmtCandidate) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	da, db := goPixFmtDescGet(a.Format), goPixFmtDescGet(b.Format)
+	if pa, pb := goGetPaddedBitsPerPixel(da),
+		goGetPaddedBitsPerPixel(db); pa != pb {
+		return pa < pb
+	}
+	return da.nbComponents < db.nbComponents
+}
@@ -0,0 +1,140 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_FindBestPixFmtOfList(t *testing.T) {
+	candidates := []pixfmts.PixelFormat{pixfmts.PixFmtRGB24,
+		pixfmts.PixFmtYUV420P, pixfmts.PixFmtGray8, pixfmts.PixFmtNV12,
+		pixfmts.PixFmtYUV444P, pixfmts.PixFmtYUVA420P, pixfmts.PixFmtPal8}
+
+	for _, src := range []pixfmts.PixelFormat{pixfmts.PixFmtYUV444P,
+		pixfmts.PixFmtYUV422P10LE, pixfmts.PixFmtRGBA, pixfmts.PixFmtGray16LE} {
+		best, loss, ranked, err := pixfmts.FindBestPixFmtOfList(candidates,
+			src, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ranked) != len(candidates) || ranked[0].Format != best {
+			t.Fatalf("%v: bad ranking %v", src, ranked)
+		}
+
+		// The winner is what folding FindBestPixFmtOf2 picks.
+		want := candidates[0]
+		for _, pf := range candidates[1:] {
+			if want, _, err = pixfmts.FindBestPixFmtOf2(want, pf, src,
+				true); err != nil {
+				t.Fatal(err)
+			}
+		}
+		wantLoss, err := pixfmts.GetPixFmtLoss(want, src, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if best != want || loss != wantLoss {
+			t.Fatalf("%v: got %v (loss %#x), want %v (loss %#x)", src,
+				best, loss, want, wantLoss)
+		}
+	}
+
+	if _, _, _, err := pixfmts.FindBestPixFmtOfList(nil, pixfmts.PixFmtRGB24,
+		false); !errors.Is(err, pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+
+	hw := []pixfmts.PixelFormat{pixfmts.PixFmtVAAPI, pixfmts.PixFmtCUDA}
+	best, _, ranked, err := pixfmts.FindBestPixFmtOfList(hw,
+		pixfmts.PixFmtNV12, false)
+	if !errors.Is(err, pixfmts.ErrNoSuitableFormat) ||
+		best != pixfmts.PixFmtNone || len(ranked) != 2 {
+		t.Fatalf("expected no suitable format, got %v, %v", best, err)
+	}
+}
+
+func Test_FindBestPixFmtOfListWeighted(t *testing.T) {
+	candidates := []pixfmts.PixelFormat{pixfmts.PixFmtRGB24,
+		pixfmts.PixFmtYUV444P10LE}
+	src := pixfmts.PixFmtGBRP10LE
+
+	// Losing depth costs libavutil more than changing the color space.
+	best, _, _, err := pixfmts.FindBestPixFmtOfList(candidates, src, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best != pixfmts.PixFmtYUV444P10LE {
+		t.Fatalf("unexpected default choice %v", best)
+	}
+
+	best, loss, _, err := pixfmts.FindBestPixFmtOfListWeighted(candidates,
+		src, false, pixfmts.LossWeights{pixfmts.FF_LOSS_COLORSPACE: 1000,
+			pixfmts.FF_LOSS_DEPTH: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best != pixfmts.PixFmtRGB24 || loss&pixfmts.FF_LOSS_DEPTH == 0 {
+		t.Fatalf("unexpected weighted choice %v (loss %#x)", best, loss)
+	}
+}
+
+func Test_FindBestPixFmtOfListWeighted_Waive(t *testing.T) {
+	candidates := []pixfmts.PixelFormat{pixfmts.PixFmtVAAPI,
+		pixfmts.PixFmtYUV444P10LE, pixfmts.PixFmtRGB24}
+	src := pixfmts.PixFmtGBRP10LE
+
+	// A zero weight waives FF_LOSS_DEPTH, so rgb24 no longer pays for it,
+	// while its reported loss still has the bit.
+	best, loss, ranked, err := pixfmts.FindBestPixFmtOfListWeighted(
+		candidates, src, false,
+		pixfmts.LossWeights{pixfmts.FF_LOSS_DEPTH: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if best != pixfmts.PixFmtRGB24 || loss&pixfmts.FF_LOSS_DEPTH == 0 {
+		t.Fatalf("unexpected weighted choice %v (loss %#x)", best, loss)
+	}
+	if last := ranked[len(ranked)-1]; last.Format != pixfmts.PixFmtVAAPI {
+		t.Fatalf("hardware format ranked before %v", last.Format)
+	}
+}
+
+// Test_FindBestPixFmtOfList_MatchOf2 checks that the scores of the generated
+// table rank every format like FindBestPixFmtOf2, and that Explain agrees
+// with GetPixFmtLoss, which use libavutil in cgo builds. Formats newer than
+// the table are rejected rather than misranked.
+func Test_FindBestPixFmtOfList_MatchOf2(t *testing.T) {
+	for pf := range pixfmts.AllPixelFormats() {
+		for _, src := range []pixfmts.PixelFormat{pixfmts.PixFmtYUV420P10LE,
+			pixfmts.PixFmtRGBA, pixfmts.PixFmtGray8} {
+			candidates := []pixfmts.PixelFormat{pixfmts.PixFmtNV12, pf,
+				pixfmts.PixFmtRGB24}
+			best, loss, _, err := pixfmts.FindBestPixFmtOfList(candidates,
+				src, true)
+			if errors.Is(err, pixfmts.ErrUnknownPixelFormat) {
+				continue
+			}
+			if err != nil {
+				t.Fatalf("%v from %v: %v", pf, src, err)
+			}
+			want := candidates[0]
+			for _, c := range candidates[1:] {
+				want, _, _ = pixfmts.FindBestPixFmtOf2(want, c, src, true)
+			}
+			wantLoss, _ := pixfmts.GetPixFmtLoss(want, src, true)
+			if best != want || loss != wantLoss {
+				t.Fatalf("%v from %v: got %v (loss %#x), want %v (loss %#x)",
+					pf, src, best, loss, want, wantLoss)
+			}
+
+			if e, err := pixfmts.Explain(pf, src); err == nil {
+				if l, _ := pixfmts.GetPixFmtLoss(pf, src, true); e.Loss != l {
+					t.Fatalf("%v from %v: Explain loss %#x, want %#x", pf,
+						src, e.Loss, l)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package gopixfmts
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOutOfBounds is returned when an image line access would touch memory
+// outside of the supplied Go slices.
+var ErrOutOfBounds = errors.New("out of bounds")
+
+// BoundsError describes an image line access that does not fit inside the
+// slice it targets. It unwraps to ErrOutOfBounds, so callers can test for it
+// with errors.Is.
+type BoundsError struct {
+	Plane int // Plane index of the failing access, or -1 for the line buffer.
+	Start int // First byte offset that would be touched.
+	End   int // One past the last byte offset that would be touched.
+	Len   int // Length of the slice that was checked.
+}
+
+func (e *BoundsError) Error() string {
+	if e.Plane < 0 {
+		return fmt.Sprintf("line buffer out of bounds: need [%d:%d], have %d",
+			e.Start, e.End, e.Len)
+	}
+	return fmt.Sprintf("plane %d out of bounds: need [%d:%d], have %d",
+		e.Plane, e.Start, e.End, e.Len)
+}
+
+func (e *BoundsError) Unwrap() error { return ErrOutOfBounds }
+
+// lineExtent returns the byte range of plane data touched when reading or
+// writing w samples of component c starting at pixel (x, y).
+//
+// The computation mirrors av_read_image_line2 and av_write_image_line2:
+// bitstream formats address bits, except for the 10-bit formats that pack a
+// pixel into a 32-bit word and always start at the beginning of the row,
+// components that fit in a byte touch one
+// byte (offset by one for big-endian formats), and everything else touches
+// 16 or 32 bit words depending on shift + depth.
+func lineExtent(desc *PixFmtDescRef, comp ComponentDescriptor, linesize,
+	x, y, w int) (start, end int) {
+	row := y * linesize
+	if desc.Flags().Has(PixFmtFlagBitstream) {
+		if comp.Depth == 10 {
+			// Every pixel is one 32-bit word holding all components, and
+			// libavutil ignores x.
+			return row, row + 4*w
+		}
+		first := comp.Offset + x*comp.Step
+		last := first + (w-1)*comp.Step
+		return row + first>>3, row + last>>3 + 1
+	}
+
+	size := 4
+	switch {
+	case comp.Shift+comp.Depth <= 8:
+		size = 1
+		if desc.Flags().Has(PixFmtFlagBigEndian) {
+			row++
+		}
+	case comp.Shift+comp.Depth <= 16:
+		size = 2
+	}
+	start = row + x*comp.Step + comp.Offset
+	return start, start + (w-1)*comp.Step + size
+}
+
+// checkImageLine verifies that an image line access of w samples of
+// component c starting at (x, y) stays inside data. When readPal is set the
+// palette lookup in data[1] is validated as well.
+//
+// A zero width access touches nothing and is always accepted.
+func checkImageLine(data [4][]byte, linesize [4]int, desc *PixFmtDescRef,
+	x, y, c, w int, readPal bool) error {
+	if c < 0 || c >= desc.NbComponents() || x < 0 || y < 0 || w < 0 {
+		return ErrInvalidArgument
+	}
+	if w == 0 {
+		return nil
+	}
+	comp, err := desc.Component(c)
+	if err != nil {
+		return err
+	}
+	if comp.Plane < 0 || comp.Plane >= len(data) {
+		return ErrInvalidArgument
+	}
+
+	plane := data[comp.Plane]
+	start, end := lineExtent(desc, comp, linesize[comp.Plane], x, y, w)
+	if start < 0 || end > len(plane) {
+		return &BoundsError{Plane: comp.Plane, Start: start, End: end,
+			Len: len(plane)}
+	}
+
+	if readPal {
+		// Palette entries are 4 bytes wide and indexed by the sample value.
+		end = 4*((1<<comp.Depth)-1) + c + 1
+		if end > len(data[1]) {
+			return &BoundsError{Plane: 1, Start: 0, End: end,
+				Len: len(data[1])}
+		}
+	}
+	return nil
+}
+
+// checkLineBuffer verifies that a line buffer of n bytes can hold w elements
+// of elementSize bytes each.
+func checkLineBuffer(n, w, elementSize int) error {
+	if need := w * elementSize; need > n {
+		return &BoundsError{Plane: -1, Start: 0, End: need, Len: n}
+	}
+	return nil
+}
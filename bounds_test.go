@@ -0,0 +1,65 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_ReadImageLine_OutOfBounds(t *testing.T) {
+	yuv420p, err := pixfmts.PixFmtDescGet(pixfmts.PixFmtYUV420P)
+	if err != nil {
+		t.FailNow()
+	}
+
+	// A 16x2 frame whose chroma planes were sized for the wrong stride.
+	data := [4][]byte{make([]byte, 32), make([]byte, 4), make([]byte, 8)}
+	linesize := [4]int{16, 8, 8}
+	dst := make([]uint16, 8)
+
+	err = pixfmts.ReadImageLine(dst, data, linesize, yuv420p, 0, 0, 1, 8,
+		false)
+	var bounds *pixfmts.BoundsError
+	if !errors.As(err, &bounds) || !errors.Is(err, pixfmts.ErrOutOfBounds) {
+		t.Fatalf("expected out of bounds error, got %v", err)
+	}
+	if bounds.Plane != 1 || bounds.End != 8 || bounds.Len != 4 {
+		t.Fatalf("unexpected bounds error: %+v", bounds)
+	}
+
+	err = pixfmts.ReadImageLine(dst, data, linesize, yuv420p, 0, 0, 2, 8,
+		false)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_WriteImageLine_OutOfBounds(t *testing.T) {
+	yuv420p10le, err := pixfmts.PixFmtDescGet(pixfmts.PixFmtYUV420P10LE)
+	if err != nil {
+		t.FailNow()
+	}
+
+	data := [4][]byte{make([]byte, 32), make([]byte, 16), make([]byte, 16)}
+	linesize := [4]int{32, 16, 16}
+	src := make([]uint16, 16)
+
+	err = pixfmts.WriteImageLine(src, data, linesize, yuv420p10le, 0, 0, 0,
+		16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = pixfmts.WriteImageLine(src, data, linesize, yuv420p10le, 1, 0, 0,
+		16)
+	if !errors.Is(err, pixfmts.ErrOutOfBounds) {
+		t.Fatalf("expected out of bounds error, got %v", err)
+	}
+
+	err = pixfmts.WriteImageLine(src[:4], data, linesize, yuv420p10le, 0, 0,
+		0, 16)
+	if !errors.Is(err, pixfmts.ErrOutOfBounds) {
+		t.Fatalf("expected short line buffer error, got %v", err)
+	}
+}
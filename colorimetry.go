@@ -0,0 +1,182 @@
+package gopixfmts
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidColorimetry is returned, wrapped with the reason, when the
+// parts of a Colorimetry contradict each other or the pixel format.
+var ErrInvalidColorimetry = errors.New("invalid colorimetry")
+
+// Colorimetry bundles the color properties that FFmpeg tracks per frame.
+type Colorimetry struct {
+	Primaries      ColorPrimaries
+	Transfer       ColorTransferCharacteristic
+	Space          ColorSpace
+	Range          ColorRange
+	ChromaLocation ChromaLocation
+}
+
+// Well known colorimetries.
+var (
+	// ColorimetryBT709 is SDR Rec. 709 video.
+	ColorimetryBT709 = Colorimetry{ColorPrimariesBT709,
+		ColorTransferCharacteristicBT709, ColorSpaceBT709, ColorRangeMPEG,
+		ChromaLocationLeft}
+
+	// ColorimetryHDR10 is BT.2020 video with the PQ transfer.
+	ColorimetryHDR10 = Colorimetry{ColorPrimariesBT2020,
+		ColorTransferCharacteristicSMPTE2084, ColorSpaceBT2020_NCL,
+		ColorRangeMPEG, ChromaLocationTopLeft}
+
+	// ColorimetryHLG is BT.2020 video with the hybrid log-gamma transfer.
+	ColorimetryHLG = Colorimetry{ColorPrimariesBT2020,
+		ColorTransferCharacteristicARIB_STD_B67, ColorSpaceBT2020_NCL,
+		ColorRangeMPEG, ChromaLocationTopLeft}
+
+	// ColorimetrySRGB is full range sRGB.
+	ColorimetrySRGB = Colorimetry{ColorPrimariesBT709,
+		ColorTransferCharacteristicIEC61966_2_1, ColorSpaceRGB,
+		ColorRangeJPEG, ChromaLocationUnspecified}
+
+	// ColorimetryDCIP3 is full range DCI-P3 RGB with the 2.6 gamma of
+	// SMPTE ST 428-1.
+	ColorimetryDCIP3 = Colorimetry{ColorPrimariesSMPTE431,
+		ColorTransferCharacteristicSMPTE428, ColorSpaceRGB, ColorRangeJPEG,
+		ChromaLocationUnspecified}
+
+	// ColorimetryDisplayP3 is full range Display P3, which pairs the P3
+	// primaries and a D65 white point with the sRGB transfer.
+	ColorimetryDisplayP3 = Colorimetry{ColorPrimariesSMPTE432,
+		ColorTransferCharacteristicIEC61966_2_1, ColorSpaceRGB,
+		ColorRangeJPEG, ChromaLocationUnspecified}
+)
+
+// String returns the canonical form of c, the FFmpeg names of its parts
+// separated by slashes in field order, such as "bt709/bt709/bt709/tv/left".
+// Values without a name are written as numbers.
+func (c Colorimetry) String() string {
+	return strings.Join([]string{
+		colorValueName(colorPrimariesNames[:], int(c.Primaries)),
+		colorValueName(colorTransferNames[:], int(c.Transfer)),
+		colorValueName(colorSpaceNames[:], int(c.Space)),
+		colorValueName(colorRangeNames[:], int(c.Range)),
+		colorValueName(chromaLocationNames[:], int(c.ChromaLocation)),
+	}, "/")
+}
+
+// ParseColorimetry parses the canonical form written by Colorimetry.String.
+// Names must match exactly, ignoring case; numbers are accepted for every
+// part. The result is not validated. Malformed input returns
+// ErrInvalidArgument.
+func ParseColorimetry(s string) (Colorimetry, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 5 {
+		return Colorimetry{}, fmt.Errorf("%w: colorimetry %q needs 5 parts",
+			ErrInvalidArgument, s)
+	}
+	var v [5]int
+	for i, names := range [][]string{colorPrimariesNames[:],
+		colorTransferNames[:], colorSpaceNames[:], colorRangeNames[:],
+		chromaLocationNames[:]} {
+		var ok bool
+		if v[i], ok = colorValueFromName(names, parts[i]); !ok {
+			return Colorimetry{}, fmt.Errorf(
+				"%w: unknown colorimetry part %q", ErrInvalidArgument, parts[i])
+		}
+	}
+	return Colorimetry{ColorPrimaries(v[0]),
+		ColorTransferCharacteristic(v[1]), ColorSpace(v[2]), ColorRange(v[3]),
+		ChromaLocation(v[4])}, nil
+}
+
+// colorValueName returns the name of v in names, or v as a number.
+func colorValueName(names []string, v int) string {
+	if v >= 0 && v < len(names) && names[v] != "" {
+		return names[v]
+	}
+	return strconv.Itoa(v)
+}
+
+// colorValueFromName is the inverse of colorValueName.
+func colorValueFromName(names []string, name string) (int, bool) {
+	v, ok := valueFromName(name, len(names),
+		func(i int) string { return names[i] })
+	if ok {
+		return v, true
+	}
+	v, err := strconv.Atoi(name)
+	return v, err == nil
+}
+
+// Validate checks that every part of c is a known value and that the parts
+// agree with each other. Unspecified parts are allowed. Failures wrap
+// ErrInvalidColorimetry.
+func (c Colorimetry) Validate() error {
+	for _, p := range []struct {
+		what  string
+		names []string
+		v     int
+	}{
+		{"primaries", colorPrimariesNames[:], int(c.Primaries)},
+		{"transfer", colorTransferNames[:], int(c.Transfer)},
+		{"color space", colorSpaceNames[:], int(c.Space)},
+		{"range", colorRangeNames[:], int(c.Range)},
+		{"chroma location", chromaLocationNames[:], int(c.ChromaLocation)},
+	} {
+		if p.v < 0 || p.v >= len(p.names) || p.names[p.v] == "" ||
+			p.names[p.v] == "reserved" {
+			return fmt.Errorf("%w: bad %s %d", ErrInvalidColorimetry, p.what,
+				p.v)
+		}
+	}
+
+	hdr := c.Transfer == ColorTransferCharacteristicSMPTE2084 ||
+		c.Transfer == ColorTransferCharacteristicARIB_STD_B67
+	switch c.Space {
+	case ColorSpaceICTCP:
+		if !hdr && c.Transfer != ColorTransferCharacteristicUnspecified {
+			return fmt.Errorf("%w: ICtCp requires the PQ or HLG transfer",
+				ErrInvalidColorimetry)
+		}
+	case ColorSpaceBT2020_NCL, ColorSpaceBT2020_CL:
+		if c.Primaries != ColorPrimariesBT2020 &&
+			c.Primaries != ColorPrimariesUnspecified {
+			return fmt.Errorf("%w: BT.2020 matrix with %s primaries",
+				ErrInvalidColorimetry,
+				colorValueName(colorPrimariesNames[:], int(c.Primaries)))
+		}
+	}
+	return nil
+}
+
+// ValidateFor checks c like Validate and also against the pixel format it
+// describes: RGB formats need the RGB color space, YUV formats a YUV one,
+// and the yuvj formats full range. Unspecified parts are allowed.
+func (c Colorimetry) ValidateFor(pf PixelFormat) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	desc, err := PixFmtDescGet(pf)
+	if err != nil {
+		return err
+	}
+	switch {
+	case desc.IsRGB() && c.Space != ColorSpaceRGB &&
+		c.Space != ColorSpaceUnspecified:
+		return fmt.Errorf("%w: %s is RGB but the color space is %s",
+			ErrInvalidColorimetry, desc.Name(),
+			colorValueName(colorSpaceNames[:], int(c.Space)))
+	case desc.IsYUV() && c.Space == ColorSpaceRGB:
+		return fmt.Errorf("%w: %s is YUV but the color space is RGB",
+			ErrInvalidColorimetry, desc.Name())
+	case strings.HasPrefix(desc.Name(), "yuvj") &&
+		c.Range == ColorRangeMPEG:
+		return fmt.Errorf("%w: %s is full range", ErrInvalidColorimetry,
+			desc.Name())
+	}
+	return nil
+}
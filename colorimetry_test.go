@@ -0,0 +1,92 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_Colorimetry_String(t *testing.T) {
+	if got := pixfmts.ColorimetryBT709.String(); got !=
+		"bt709/bt709/bt709/tv/left" {
+		t.Fatalf("unexpected canonical form %q", got)
+	}
+	for _, c := range []pixfmts.Colorimetry{pixfmts.ColorimetryBT709,
+		pixfmts.ColorimetryHDR10, pixfmts.ColorimetryHLG,
+		pixfmts.ColorimetrySRGB, pixfmts.ColorimetryDCIP3,
+		pixfmts.ColorimetryDisplayP3,
+		{Space: pixfmts.ColorSpaceYCGCO_RE, Range: 7}} {
+		got, err := pixfmts.ParseColorimetry(c.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c {
+			t.Fatalf("%v parsed as %v", c, got)
+		}
+	}
+	if _, err := pixfmts.ParseColorimetry("bt709/bt709"); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
+
+func Test_Colorimetry_Validate(t *testing.T) {
+	for _, c := range []pixfmts.Colorimetry{pixfmts.ColorimetryBT709,
+		pixfmts.ColorimetryHDR10, pixfmts.ColorimetryHLG,
+		pixfmts.ColorimetrySRGB, pixfmts.ColorimetryDCIP3,
+		pixfmts.ColorimetryDisplayP3} {
+		if err := c.Validate(); err != nil {
+			t.Fatalf("%v: %v", c, err)
+		}
+	}
+
+	ictcp := pixfmts.ColorimetryBT709
+	ictcp.Space = pixfmts.ColorSpaceICTCP
+	if err := ictcp.Validate(); !errors.Is(err,
+		pixfmts.ErrInvalidColorimetry) {
+		t.Fatalf("ICtCp with BT.709 transfer: %v", err)
+	}
+	ictcp.Transfer = pixfmts.ColorTransferCharacteristicUnspecified
+	if err := ictcp.Validate(); err != nil {
+		t.Fatalf("ICtCp with unspecified transfer: %v", err)
+	}
+	if err := pixfmts.ColorimetrySRGB.ValidateFor(
+		pixfmts.PixFmtYUV420P); !errors.Is(err,
+		pixfmts.ErrInvalidColorimetry) {
+		t.Fatalf("RGB color space on yuv420p: %v", err)
+	}
+	if err := pixfmts.ColorimetryHDR10.ValidateFor(
+		pixfmts.PixFmtYUV420P10LE); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Test_ParseColorimetry_MatchesUnmarshalText parses every primaries name,
+// upper-cased to exercise the case policy, through ParseColorimetry and
+// UnmarshalText and requires them to agree.
+func Test_ParseColorimetry_MatchesUnmarshalText(t *testing.T) {
+	for p := range pixfmts.AllColorPrimaries() {
+		name := strings.ToUpper(p.String())
+		c, err := pixfmts.ParseColorimetry(name + "/bt709/bt709/tv/left")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		var got pixfmts.ColorPrimaries
+		if err := got.UnmarshalText([]byte(name)); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if c.Primaries != got || got != p {
+			t.Fatalf("%s: ParseColorimetry gave %v, UnmarshalText %v, want %v",
+				name, c.Primaries, got, p)
+		}
+	}
+
+	var s pixfmts.ColorSpace
+	_, perr := pixfmts.ParseColorimetry("bt709/bt709/ycgco-rex/tv/left")
+	if uerr := s.UnmarshalText([]byte("ycgco-rex")); perr == nil ||
+		uerr == nil {
+		t.Fatalf("prefix accepted: %v, %v", perr, uerr)
+	}
+}
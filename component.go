@@ -0,0 +1,168 @@
+package gopixfmts
+
+import "unsafe"
+
+// ComponentSamples holds every sample of one component of a frame, widened to
+// uint16, in row-major order. Row y starts at Samples[y*Stride].
+type ComponentSamples struct {
+	Width   int
+	Height  int
+	Stride  int
+	Samples []uint16
+}
+
+// ComponentSamples32 is the uint32 counterpart of ComponentSamples, used for
+// components deeper than 16 bits such as 32-bit float planes.
+type ComponentSamples32 struct {
+	Width   int
+	Height  int
+	Stride  int
+	Samples []uint32
+}
+
+// componentSize returns the number of samples per row and rows of component
+// c of a width x height image. Chroma components are reduced by the
+// descriptor's subsampling factors, even when they share a plane with luma
+// as in packed 4:2:2 formats.
+func componentSize(desc *PixFmtDescRef, c, width, height int) (w, h int) {
+	if c == 1 || c == 2 {
+		return shiftCeil(width, desc.Log2ChromaW()),
+			shiftCeil(height, desc.Log2ChromaH())
+	}
+	return width, height
+}
+
+// checkComponentSamples verifies that a buffer of n samples with the given
+// geometry can hold a w x h component.
+func checkComponentSamples(n, width, height, stride, w, h int) error {
+	if width != w || height != h || stride < w {
+		return ErrInvalidArgument
+	}
+	if h == 0 {
+		return nil
+	}
+	if need := (h-1)*stride + w; need > n {
+		return &BoundsError{Plane: -1, Start: 0, End: need, Len: n}
+	}
+	return nil
+}
+
+// ReadComponent reads every sample of component c of the frame into a dense
+// buffer whose Stride equals its Width.
+//
+// Rows are read with ReadImageLine, so samples deeper than 16 bits are
+// truncated; use ReadComponent32 for those. Paletted formats yield the
+// palette indexes. An invalid component returns ErrInvalidArgument.
+func ReadComponent(f *Frame, c int) (*ComponentSamples, error) {
+	if f == nil || c < 0 || c >= f.desc.NbComponents() {
+		return nil, ErrInvalidArgument
+	}
+	w, h := componentSize(f.desc, c, f.width, f.height)
+	out := &ComponentSamples{Width: w, Height: h, Stride: w,
+		Samples: make([]uint16, w*h)}
+	for y := 0; y < h; y++ {
+		row := out.Samples[y*w : (y+1)*w]
+		if err := f.ReadImageLine(row, 0, y, c, w, false); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// ReadComponent32 reads every sample of component c of the frame into a
+// dense buffer of uint32 samples whose Stride equals its Width.
+//
+// Rows are read with ReadImageLine2 using 4 byte elements. An invalid
+// component returns ErrInvalidArgument.
+func ReadComponent32(f *Frame, c int) (*ComponentSamples32, error) {
+	if f == nil || c < 0 || c >= f.desc.NbComponents() {
+		return nil, ErrInvalidArgument
+	}
+	w, h := componentSize(f.desc, c, f.width, f.height)
+	out := &ComponentSamples32{Width: w, Height: h, Stride: w,
+		Samples: make([]uint32, w*h)}
+	for y := 0; y < h; y++ {
+		row := uint32Bytes(out.Samples[y*w : (y+1)*w])
+		if err := f.ReadImageLine2(row, 0, y, c, w, false, 4); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// WriteComponent writes every sample of component c of the frame from
+// samples.
+//
+// Existing values of the component are replaced. Unlike WriteImageLine,
+// which ORs samples into place like av_write_image_line2, the bits of
+// component c are cleared before each sample is stored, while other
+// components sharing the same bytes are left untouched. Samples are masked
+// to the component's depth, so out of range values cannot spill into them.
+//
+// The Width and Height of samples must match the component's size, which is
+// the frame size reduced by the chroma subsampling factors for components 1
+// and 2. Mismatched geometry or a Stride smaller than Width returns
+// ErrInvalidArgument, and a Samples slice that is too short returns a
+// *BoundsError.
+func WriteComponent(f *Frame, c int, samples *ComponentSamples) error {
+	if f == nil || samples == nil || c < 0 || c >= f.desc.NbComponents() {
+		return ErrInvalidArgument
+	}
+	w, h := componentSize(f.desc, c, f.width, f.height)
+	err := checkComponentSamples(len(samples.Samples), samples.Width,
+		samples.Height, samples.Stride, w, h)
+	if err != nil {
+		return err
+	}
+	for y := 0; y < h; y++ {
+		off := y * samples.Stride
+		row := samples.Samples[off : off+w]
+		if err := writeComponentLine(f, row, y, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteComponent32 writes every sample of component c of the frame from
+// uint32 samples. Existing values are replaced and the geometry rules are the
+// same as for WriteComponent.
+func WriteComponent32(f *Frame, c int, samples *ComponentSamples32) error {
+	if f == nil || samples == nil || c < 0 || c >= f.desc.NbComponents() {
+		return ErrInvalidArgument
+	}
+	w, h := componentSize(f.desc, c, f.width, f.height)
+	err := checkComponentSamples(len(samples.Samples), samples.Width,
+		samples.Height, samples.Stride, w, h)
+	if err != nil {
+		return err
+	}
+	for y := 0; y < h; y++ {
+		off := y * samples.Stride
+		row := samples.Samples[off : off+w]
+		if err := writeComponentLine(f, row, y, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeComponentLine replaces row y of component c with line, starting at
+// the left edge of the frame.
+func writeComponentLine[T sample](f *Frame, line []T, y, c int) error {
+	err := checkImageLine(f.data, f.linesize, f.desc, 0, y, c, len(line),
+		false)
+	if err != nil {
+		return err
+	}
+	writeLine(line, f.data, f.linesize, f.desc, 0, y, c, true)
+	return nil
+}
+
+// uint32Bytes reinterprets s as its native-endian bytes without copying.
+func uint32Bytes(s []uint32) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&s[0])), 4*len(s))
+}
@@ -0,0 +1,171 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_WriteReadComponent_Y210(t *testing.T) {
+	frame, err := pixfmts.NewFrame(pixfmts.PixFmtY210LE, 5, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The U samples of a packed 4:2:2 frame are half as wide as the frame.
+	u := &pixfmts.ComponentSamples{Width: 3, Height: 2, Stride: 4,
+		Samples: []uint16{1, 2, 3, 0, 1021, 1022, 1023, 0}}
+	if err := pixfmts.WriteComponent(frame, 1, u); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := pixfmts.ReadComponent(frame, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Width != 3 || got.Height != 2 || got.Stride != 3 {
+		t.Fatalf("unexpected geometry: %dx%d stride %d", got.Width,
+			got.Height, got.Stride)
+	}
+	want := []uint16{1, 2, 3, 1021, 1022, 1023}
+	for i := range want {
+		if got.Samples[i] != want[i] {
+			t.Fatalf("sample %d: got %d want %d", i, got.Samples[i], want[i])
+		}
+	}
+
+	luma, err := pixfmts.ReadComponent(frame, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range luma.Samples {
+		if s != 0 {
+			t.Fatalf("luma sample %d was overwritten: %d", i, s)
+		}
+	}
+}
+
+func Test_WriteComponent_Geometry(t *testing.T) {
+	frame, err := pixfmts.NewFrame(pixfmts.PixFmtYUV420P, 5, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrongSize := &pixfmts.ComponentSamples{Width: 5, Height: 3, Stride: 5,
+		Samples: make([]uint16, 15)}
+	err = pixfmts.WriteComponent(frame, 2, wrongSize)
+	if !errors.Is(err, pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+
+	short := &pixfmts.ComponentSamples{Width: 3, Height: 2, Stride: 3,
+		Samples: make([]uint16, 5)}
+	err = pixfmts.WriteComponent(frame, 2, short)
+	if !errors.Is(err, pixfmts.ErrOutOfBounds) {
+		t.Fatalf("expected out of bounds error, got %v", err)
+	}
+
+	if _, err := pixfmts.ReadComponent(frame, 3); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
+
+func Test_WriteComponent_ReplacesSamples(t *testing.T) {
+	frame, err := pixfmts.NewFrame(pixfmts.PixFmtYUV420P, 8, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := &pixfmts.ComponentSamples{Width: 8, Height: 1, Stride: 8,
+		Samples: []uint16{1, 2, 4, 8, 16, 32, 64, 128}}
+	second := &pixfmts.ComponentSamples{Width: 8, Height: 1, Stride: 8,
+		Samples: []uint16{2, 1, 1, 1, 1, 1, 1, 1}}
+	for _, s := range []*pixfmts.ComponentSamples{first, second} {
+		if err := pixfmts.WriteComponent(frame, 0, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := pixfmts.ReadComponent(frame, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range second.Samples {
+		if got.Samples[i] != want {
+			t.Fatalf("sample %d: got %d want %d", i, got.Samples[i], want)
+		}
+	}
+
+	// Packed formats keep the neighbouring components intact.
+	rgb, err := pixfmts.NewFrame(pixfmts.PixFmtRGB565LE, 2, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writes := []struct {
+		c       int
+		samples []uint32
+	}{{1, []uint32{63, 63}}, {0, []uint32{31, 5}}, {0, []uint32{9, 0}}}
+	for _, w := range writes {
+		s := &pixfmts.ComponentSamples32{Width: 2, Height: 1, Stride: 2,
+			Samples: w.samples}
+		if err := pixfmts.WriteComponent32(rgb, w.c, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r, err := pixfmts.ReadComponent32(rgb, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := pixfmts.ReadComponent32(rgb, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Samples[0] != 9 || r.Samples[1] != 0 || g.Samples[0] != 63 ||
+		g.Samples[1] != 63 {
+		t.Fatalf("got red %v green %v", r.Samples, g.Samples)
+	}
+}
+
+func Test_WriteComponent_MasksSamples(t *testing.T) {
+	rgb, err := pixfmts.NewFrame(pixfmts.PixFmtRGB565LE, 1, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 0x3f has one bit more than the 5-bit blue component, which sits
+	// directly below green.
+	blue := &pixfmts.ComponentSamples{Width: 1, Height: 1, Stride: 1,
+		Samples: []uint16{0x3f}}
+	if err := pixfmts.WriteComponent(rgb, 2, blue); err != nil {
+		t.Fatal(err)
+	}
+	g, err := pixfmts.ReadComponent(rgb, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := pixfmts.ReadComponent(rgb, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Samples[0] != 0x1f || g.Samples[0] != 0 {
+		t.Fatalf("got blue %#x green %#x", b.Samples[0], g.Samples[0])
+	}
+
+	mono, err := pixfmts.NewFrame(pixfmts.PixFmtMonoBlack, 2, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bits := &pixfmts.ComponentSamples{Width: 2, Height: 1, Stride: 2,
+		Samples: []uint16{0, 2}}
+	if err := pixfmts.WriteComponent(mono, 0, bits); err != nil {
+		t.Fatal(err)
+	}
+	got, err := pixfmts.ReadComponent(mono, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Samples[0] != 0 || got.Samples[1] != 0 {
+		t.Fatalf("got %v, want [0 0]", got.Samples)
+	}
+}
@@ -0,0 +1,454 @@
+package gopixfmts
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Convert converts the image in src into the pixel format of dst. Both
+// frames must have the same dimensions.
+//
+// Pairs with a registered fast path, such as the built-in ones between nv12
+// and yuv420p or p010le and yuv420p10le, or those added with
+// RegisterConversion, are converted directly. Every other pair goes through
+// normalized float32 planes read with the format descriptors, which covers
+// packing changes, bit depth changes, endianness swaps and chroma
+// resampling. Conversions between YUV, RGB and gray families use the BT.601
+// matrix, limited range for YUV formats except the yuvj formats, full range
+// for gray formats and left sited chroma. Paletted destinations get the
+// Plan 9 palette.
+//
+// Every component of dst is overwritten, so a frame may be reused as the
+// destination of several conversions.
+//
+// Hardware accelerated, Bayer and XYZ formats return ErrInvalidArgument, as
+// do mismatched dimensions and dst aliasing src.
+func Convert(dst, src *Frame) error {
+	if dst == nil || src == nil || dst == src || dst.width != src.width ||
+		dst.height != src.height {
+		return ErrInvalidArgument
+	}
+	if fn := lookupConversion(src.format, dst.format); fn != nil {
+		return fn(dst, src)
+	}
+	if dst.format == src.format {
+		copyFrame(dst, src)
+		return nil
+	}
+	return convertGeneric(dst, src)
+}
+
+// ---------------- Generic path ----------------
+
+// normPlanes holds an image as normalized float32 planes. RGB planes are
+// R, G, B and alpha, gray planes luma and alpha, and YUV planes luma, chroma
+// in [-0.5, 0.5] and alpha, all in [0, 1] otherwise. Chroma planes are
+// reduced by sw and sh. A nil alpha plane is opaque.
+type normPlanes struct {
+	kind   imageKind
+	w, h   int
+	sw, sh int
+	c      [4][]float32
+}
+
+// convertGeneric converts src into dst through normalized planes.
+func convertGeneric(dst, src *Frame) error {
+	dstKind, err := imageKindOf(dst.desc)
+	if err != nil {
+		return err
+	}
+	p, err := loadFrame(src)
+	if err != nil {
+		return err
+	}
+	xpos, ypos, _ := goChromaLocationEnumToPos(int(ChromaLocationLeft))
+
+	switch dstKind {
+	case imageRGB, imagePaletted:
+		p = p.toRGB(xpos, ypos)
+	case imageGray:
+		if p.kind == imageRGB {
+			p = p.toYUV()
+		}
+		p.kind, p.c[1], p.c[2] = imageGray, nil, nil
+	case imageYCbCr:
+		switch p.kind {
+		case imageRGB:
+			p = p.toYUV()
+		case imageGray:
+			p.kind = imageYCbCr
+			p.c[1] = make([]float32, len(p.c[0]))
+			p.c[2] = make([]float32, len(p.c[0]))
+		}
+		dsw, dsh := dst.desc.Log2ChromaW(), dst.desc.Log2ChromaH()
+		for c := 1; c <= 2; c++ {
+			p.c[c] = resampleChroma(p.c[c], p.w, p.h, p.sw, p.sh, dsw, dsh,
+				xpos, ypos)
+		}
+		p.sw, p.sh = dsw, dsh
+	}
+	if p.c[3] == nil && dst.desc.HasAlpha() {
+		p.c[3] = make([]float32, p.w*p.h)
+		for i := range p.c[3] {
+			p.c[3][i] = 1
+		}
+	}
+	dst.storeFrame(dstKind, p)
+	return nil
+}
+
+// toRGB returns p with its planes converted to full resolution R'G'B'.
+func (p normPlanes) toRGB(xpos, ypos int) normPlanes {
+	switch p.kind {
+	case imageGray:
+		p.c[1], p.c[2] = p.c[0], p.c[0]
+	case imageYCbCr:
+		u := resampleChroma(p.c[1], p.w, p.h, p.sw, p.sh, 0, 0, xpos, ypos)
+		v := resampleChroma(p.c[2], p.w, p.h, p.sw, p.sh, 0, 0, xpos, ypos)
+		var rgb [3][]float32
+		for i := range rgb {
+			rgb[i] = make([]float32, p.w*p.h)
+		}
+		_, inv, _ := matricesOf(ColorSpaceUnspecified)
+		for i := range rgb[0] {
+			r, g, b := inv.Apply(float64(p.c[0][i]), float64(u[i]),
+				float64(v[i]))
+			rgb[0][i], rgb[1][i], rgb[2][i] = float32(r), float32(g),
+				float32(b)
+		}
+		p.c[0], p.c[1], p.c[2] = rgb[0], rgb[1], rgb[2]
+	}
+	p.kind, p.sw, p.sh = imageRGB, 0, 0
+	return p
+}
+
+// toYUV returns the RGB planes of p converted to full resolution YUV.
+func (p normPlanes) toYUV() normPlanes {
+	var yuv [3][]float32
+	for i := range yuv {
+		yuv[i] = make([]float32, p.w*p.h)
+	}
+	fwd, _, _ := matricesOf(ColorSpaceUnspecified)
+	for i := range yuv[0] {
+		y, u, v := fwd.Apply(float64(p.c[0][i]), float64(p.c[1][i]),
+			float64(p.c[2][i]))
+		yuv[0][i], yuv[1][i], yuv[2][i] = float32(y), float32(u), float32(v)
+	}
+	p.c[0], p.c[1], p.c[2] = yuv[0], yuv[1], yuv[2]
+	p.kind = imageYCbCr
+	return p
+}
+
+// defaultFullRange reports whether samples of desc are full range when
+// nothing else is known: always for RGB and gray formats, and for YUV
+// formats only for the yuvj formats.
+func defaultFullRange(desc *PixFmtDescRef) bool {
+	return !desc.IsYUV() || strings.HasPrefix(desc.Name(), "yuvj")
+}
+
+// loadFrame reads every component of f into normalized planes. Paletted
+// frames are resolved to RGB.
+func loadFrame(f *Frame) (normPlanes, error) {
+	kind, err := imageKindOf(f.desc)
+	if err != nil {
+		return normPlanes{}, err
+	}
+	p := normPlanes{kind: kind, w: f.width, h: f.height}
+	full := defaultFullRange(f.desc)
+	alpha := f.desc.NbComponents() - 1
+
+	switch kind {
+	case imagePaletted:
+		p.kind = imageRGB
+		p.c = f.loadPaletted()
+		return p, nil
+	case imageRGB:
+		for c := 0; c < 3; c++ {
+			p.c[c] = f.loadPlane(c, decodeNormalized)
+		}
+	case imageGray:
+		p.c[0] = f.loadLevels(0, full, false)
+	case imageYCbCr:
+		p.sw, p.sh = f.desc.Log2ChromaW(), f.desc.Log2ChromaH()
+		for c := 0; c < 3; c++ {
+			p.c[c] = f.loadLevels(c, full, c > 0)
+		}
+	}
+	if f.desc.HasAlpha() {
+		p.c[3] = f.loadPlane(alpha, decodeNormalized)
+	}
+	return p, nil
+}
+
+// storeFrame writes normalized planes of the matching kind into f.
+func (f *Frame) storeFrame(kind imageKind, p normPlanes) {
+	full := defaultFullRange(f.desc)
+	alpha := f.desc.NbComponents() - 1
+
+	switch kind {
+	case imagePaletted:
+		f.storePaletted(p.c)
+		return
+	case imageRGB:
+		for c := 0; c < 3; c++ {
+			f.storePlane(c, p.c[c], identity)
+		}
+	case imageGray:
+		f.storeLevels(0, p.c[0], full, false)
+	case imageYCbCr:
+		for c := 0; c < 3; c++ {
+			f.storeLevels(c, p.c[c], full, c > 0)
+		}
+	}
+	if f.desc.HasAlpha() {
+		f.storePlane(alpha, p.c[3], identity)
+	}
+}
+
+// loadPlane reads every sample of component c, mapped to a normalized value
+// by norm, which receives the component depth, whether the format is
+// floating point and the stored value.
+func (f *Frame) loadPlane(c int,
+	norm func(int, bool, uint32) float64) []float32 {
+	comp, _ := f.desc.Component(c)
+	float := f.desc.IsFloat()
+	w, h := componentSize(f.desc, c, f.width, f.height)
+	plane := make([]float32, w*h)
+	row := make([]uint32, w)
+	for y := 0; y < h; y++ {
+		readLine(row, f.data, f.linesize, f.desc, 0, y, c, false)
+		for x, v := range row {
+			plane[y*w+x] = float32(norm(comp.Depth, float, v))
+		}
+	}
+	return plane
+}
+
+// loadLevels is the inverse of storeLevels.
+func (f *Frame) loadLevels(c int, full, chroma bool) []float32 {
+	comp, _ := f.desc.Component(c)
+	levels := levelsOf(comp.Depth, full)
+	invert := f.format == PixFmtMonoWhite
+	return f.loadPlane(c, func(depth int, float bool, v uint32) float64 {
+		n := decodeNormalized(depth, float, v)
+		switch {
+		case float:
+			if chroma {
+				return n - 0.5
+			}
+			return n
+		case invert:
+			return 1 - n
+		}
+		return levels.Normalize(int(v), chroma)
+	})
+}
+
+// loadPaletted resolves the indexes of a paletted frame to straight alpha
+// RGBA planes.
+func (f *Frame) loadPaletted() (rgba [4][]float32) {
+	for i := range rgba {
+		rgba[i] = make([]float32, f.width*f.height)
+	}
+	idx := f.loadPlane(0, func(_ int, _ bool, v uint32) float64 {
+		return float64(v)
+	})
+	for i, v := range idx {
+		e := binary.NativeEndian.Uint32(f.data[1][4*int(v):])
+		rgba[0][i] = float32(e>>16&0xff) / 0xff
+		rgba[1][i] = float32(e>>8&0xff) / 0xff
+		rgba[2][i] = float32(e&0xff) / 0xff
+		rgba[3][i] = float32(e>>24) / 0xff
+	}
+	return rgba
+}
+
+// decodeNormalized is the inverse of encodeNormalized, without clamping.
+func decodeNormalized(depth int, float bool, v uint32) float64 {
+	if float {
+		if depth == 16 {
+			return halfToFloat(uint16(v))
+		}
+		return float64(math.Float32frombits(v))
+	}
+	return float64(v) / float64(uint64(1)<<depth-1)
+}
+
+// copyFrame copies the planes of src into dst, which has the same format
+// and dimensions.
+func copyFrame(dst, src *Frame) {
+	for i := 0; i < 4; i++ {
+		n := dst.desc.PlaneHeight(i, dst.height)
+		width, _ := dst.desc.MinLinesize(i, dst.width)
+		for y := 0; y < n; y++ {
+			copy(dst.data[i][y*dst.linesize[i]:][:width],
+				src.data[i][y*src.linesize[i]:])
+		}
+	}
+	if dst.desc.IsPaletted() {
+		copy(dst.data[1], src.data[1])
+	}
+}
+
+// ---------------- Fast paths ----------------
+
+// ConversionFunc converts src into dst. Both frames have the pixel formats
+// the function was registered for and the same dimensions. It must overwrite
+// every component of dst.
+type ConversionFunc func(dst, src *Frame) error
+
+// conversion identifies a source and destination pixel format pair.
+type conversion struct {
+	src, dst PixelFormat
+}
+
+// conversionsMu guards fastConversions.
+var conversionsMu sync.RWMutex
+
+// fastConversions maps format pairs to direct conversion functions. The
+// built-in ones must produce exactly what convertGeneric produces for the
+// pair.
+var fastConversions = map[conversion]ConversionFunc{
+	{PixFmtNV12, PixFmtYUV420P}:       repack(semiPlanarToPlanar8),
+	{PixFmtNV21, PixFmtYUV420P}:       repack(semiPlanarToPlanar8),
+	{PixFmtYUV420P, PixFmtNV12}:       repack(planarToSemiPlanar8),
+	{PixFmtYUV420P, PixFmtNV21}:       repack(planarToSemiPlanar8),
+	{PixFmtP010LE, PixFmtYUV420P10LE}: repack(semiPlanarToPlanar16),
+	{PixFmtYUV420P10LE, PixFmtP010LE}: repack(planarToSemiPlanar16),
+}
+
+// repack adapts a repacking function that cannot fail to a ConversionFunc.
+func repack(fn func(dst, src *Frame)) ConversionFunc {
+	return func(dst, src *Frame) error {
+		fn(dst, src)
+		return nil
+	}
+}
+
+// RegisterConversion sets fn as the fast path Convert uses from src to dst,
+// replacing any earlier one, including the built-in ones. A nil fn removes
+// the fast path, so the pair goes through the generic path again.
+//
+// It is safe to call concurrently with Convert. Unknown formats return
+// ErrUnknownPixelFormat and hardware accelerated formats return
+// ErrInvalidArgument.
+func RegisterConversion(src, dst PixelFormat, fn ConversionFunc) error {
+	for _, pf := range []PixelFormat{src, dst} {
+		desc, err := PixFmtDescGet(pf)
+		if err != nil {
+			return err
+		}
+		if desc.IsHWAccel() {
+			return ErrInvalidArgument
+		}
+	}
+	conversionsMu.Lock()
+	defer conversionsMu.Unlock()
+	if fn == nil {
+		delete(fastConversions, conversion{src, dst})
+	} else {
+		fastConversions[conversion{src, dst}] = fn
+	}
+	return nil
+}
+
+// lookupConversion returns the fast path from src to dst, or nil.
+func lookupConversion(src, dst PixelFormat) ConversionFunc {
+	conversionsMu.RLock()
+	defer conversionsMu.RUnlock()
+	return fastConversions[conversion{src, dst}]
+}
+
+// chromaOrder returns the byte offsets of Cb and Cr within the interleaved
+// chroma plane of a semi-planar format.
+func chromaOrder(desc *PixFmtDescRef) (cb, cr int) {
+	u, _ := desc.Component(1)
+	v, _ := desc.Component(2)
+	return u.Offset, v.Offset
+}
+
+// copyLuma copies the rows of plane 0, n bytes each.
+func copyLuma(dst, src *Frame, n int) {
+	for y := 0; y < src.height; y++ {
+		copy(dst.data[0][y*dst.linesize[0]:][:n],
+			src.data[0][y*src.linesize[0]:])
+	}
+}
+
+// semiPlanarToPlanar8 splits the chroma plane of nv12 or nv21.
+func semiPlanarToPlanar8(dst, src *Frame) {
+	copyLuma(dst, src, src.width)
+	cw, ch := src.PlaneSize(1)
+	cb, cr := chromaOrder(src.desc)
+	for y := 0; y < ch; y++ {
+		in := src.data[1][y*src.linesize[1]:]
+		u := dst.data[1][y*dst.linesize[1]:]
+		v := dst.data[2][y*dst.linesize[2]:]
+		for x := 0; x < cw; x++ {
+			u[x], v[x] = in[2*x+cb], in[2*x+cr]
+		}
+	}
+}
+
+// planarToSemiPlanar8 interleaves the chroma planes into nv12 or nv21.
+func planarToSemiPlanar8(dst, src *Frame) {
+	copyLuma(dst, src, src.width)
+	cw, ch := dst.PlaneSize(1)
+	cb, cr := chromaOrder(dst.desc)
+	for y := 0; y < ch; y++ {
+		u := src.data[1][y*src.linesize[1]:]
+		v := src.data[2][y*src.linesize[2]:]
+		out := dst.data[1][y*dst.linesize[1]:]
+		for x := 0; x < cw; x++ {
+			out[2*x+cb], out[2*x+cr] = u[x], v[x]
+		}
+	}
+}
+
+// semiPlanarToPlanar16 converts p010le into yuv420p10le, moving the samples
+// from the high to the low bits of each 16-bit word.
+func semiPlanarToPlanar16(dst, src *Frame) {
+	le := binary.LittleEndian
+	for y := 0; y < src.height; y++ {
+		in := src.data[0][y*src.linesize[0]:]
+		out := dst.data[0][y*dst.linesize[0]:]
+		for x := 0; x < src.width; x++ {
+			le.PutUint16(out[2*x:], le.Uint16(in[2*x:])>>6)
+		}
+	}
+	cw, ch := src.PlaneSize(1)
+	for y := 0; y < ch; y++ {
+		in := src.data[1][y*src.linesize[1]:]
+		u := dst.data[1][y*dst.linesize[1]:]
+		v := dst.data[2][y*dst.linesize[2]:]
+		for x := 0; x < cw; x++ {
+			le.PutUint16(u[2*x:], le.Uint16(in[4*x:])>>6)
+			le.PutUint16(v[2*x:], le.Uint16(in[4*x+2:])>>6)
+		}
+	}
+}
+
+// planarToSemiPlanar16 converts yuv420p10le into p010le. Bits above the
+// 10-bit range are dropped, as the generic path does.
+func planarToSemiPlanar16(dst, src *Frame) {
+	le := binary.LittleEndian
+	for y := 0; y < src.height; y++ {
+		in := src.data[0][y*src.linesize[0]:]
+		out := dst.data[0][y*dst.linesize[0]:]
+		for x := 0; x < src.width; x++ {
+			le.PutUint16(out[2*x:], le.Uint16(in[2*x:])<<6)
+		}
+	}
+	cw, ch := dst.PlaneSize(1)
+	for y := 0; y < ch; y++ {
+		u := src.data[1][y*src.linesize[1]:]
+		v := src.data[2][y*src.linesize[2]:]
+		out := dst.data[1][y*dst.linesize[1]:]
+		for x := 0; x < cw; x++ {
+			le.PutUint16(out[4*x:], le.Uint16(u[2*x:])<<6)
+			le.PutUint16(out[4*x+2:], le.Uint16(v[2*x:])<<6)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func newFrame(t *testing.T, pf pixfmts.PixelFormat, w, h int) *pixfmts.Frame {
+	t.Helper()
+	frame, err := pixfmts.NewFrame(pf, w, h, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return frame
+}
+
+func convert(t *testing.T, src *pixfmts.Frame,
+	pf pixfmts.PixelFormat) *pixfmts.Frame {
+	t.Helper()
+	dst := newFrame(t, pf, src.Width(), src.Height())
+	if err := pixfmts.Convert(dst, src); err != nil {
+		t.Fatal(err)
+	}
+	return dst
+}
+
+func Test_Convert_FastPaths(t *testing.T) {
+	// Fast paths and the generic path through a format holding the same
+	// samples must both preserve every component exactly.
+	tests := []struct {
+		src, fast, generic pixfmts.PixelFormat
+	}{
+		{pixfmts.PixFmtNV12, pixfmts.PixFmtYUV420P, pixfmts.PixFmtYUVA420P},
+		{pixfmts.PixFmtNV21, pixfmts.PixFmtYUV420P, pixfmts.PixFmtYUVA420P},
+		{pixfmts.PixFmtYUV420P, pixfmts.PixFmtNV12, pixfmts.PixFmtYUVA420P},
+		{pixfmts.PixFmtYUV420P, pixfmts.PixFmtNV21, pixfmts.PixFmtYUVA420P},
+		{pixfmts.PixFmtP010LE, pixfmts.PixFmtYUV420P10LE,
+			pixfmts.PixFmtYUV420P10BE},
+		{pixfmts.PixFmtYUV420P10LE, pixfmts.PixFmtP010LE,
+			pixfmts.PixFmtP010BE},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, tc := range tests {
+		src := newFrame(t, tc.src, 7, 5)
+		for _, p := range src.Planes() {
+			rng.Read(p)
+		}
+		fast, generic := convert(t, src, tc.fast), convert(t, src, tc.generic)
+		for c := 0; c < 3; c++ {
+			want, err := pixfmts.ReadComponent(src, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range []*pixfmts.Frame{fast, generic} {
+				got, err := pixfmts.ReadComponent(f, c)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !slices.Equal(got.Samples, want.Samples) {
+					t.Fatalf("%v -> %v: component %d differs", tc.src,
+						f.Format(), c)
+				}
+			}
+		}
+	}
+}
+
+func Test_Convert_BitDepth(t *testing.T) {
+	src := newFrame(t, pixfmts.PixFmtYUV420P, 4, 4)
+	planes := src.Planes()
+	for i := range planes[0] {
+		planes[0][i] = 235
+	}
+	for i := range planes[1] {
+		planes[1][i], planes[2][i] = 128, 240
+	}
+
+	dst := convert(t, src, pixfmts.PixFmtYUV444P10BE)
+	for c, want := range []uint16{940, 512, 960} {
+		s, err := pixfmts.ReadComponent(dst, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range s.Samples {
+			if v != want {
+				t.Fatalf("component %d: got %d, want %d", c, v, want)
+			}
+		}
+	}
+}
+
+func Test_Convert_RGBRoundTrip(t *testing.T) {
+	src := newFrame(t, pixfmts.PixFmtRGB24, 5, 3)
+	rand.New(rand.NewSource(2)).Read(src.Planes()[0])
+
+	back := convert(t, convert(t, src, pixfmts.PixFmtGBRP16LE),
+		pixfmts.PixFmtRGB24)
+	for y := 0; y < src.Height(); y++ {
+		a := src.Planes()[0][y*src.Linesizes()[0]:][:3*src.Width()]
+		b := back.Planes()[0][y*back.Linesizes()[0]:][:3*back.Width()]
+		if !slices.Equal(a, b) {
+			t.Fatalf("row %d changed: % x != % x", y, b, a)
+		}
+	}
+}
+
+func Test_Convert_Mismatch(t *testing.T) {
+	src := newFrame(t, pixfmts.PixFmtGray8, 2, 2)
+	dst := newFrame(t, pixfmts.PixFmtGray8, 2, 3)
+	if err := pixfmts.Convert(dst, src); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
+
+func Test_Convert_ReusedDst(t *testing.T) {
+	// Converting into a frame that already holds an image must give the
+	// same result as converting into a fresh one.
+	fill := func(v byte) *pixfmts.Frame {
+		src := newFrame(t, pixfmts.PixFmtRGB24, 4, 2)
+		for _, p := range src.Planes() {
+			for i := range p {
+				p[i] = v
+			}
+		}
+		return src
+	}
+	dst := newFrame(t, pixfmts.PixFmtYUV444P, 4, 2)
+	for _, v := range []byte{0x10, 0x40} {
+		if err := pixfmts.Convert(dst, fill(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := convert(t, fill(0x40), pixfmts.PixFmtYUV444P)
+	for c := 0; c < 3; c++ {
+		got, err := pixfmts.ReadComponent(dst, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp, err := pixfmts.ReadComponent(want, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got.Samples, exp.Samples) {
+			t.Fatalf("component %d: got %v, want %v", c, got.Samples,
+				exp.Samples)
+		}
+	}
+}
+
+func Test_RegisterConversion(t *testing.T) {
+	src := newFrame(t, pixfmts.PixFmtGray8, 4, 2)
+	errCustom := errors.New("custom conversion")
+	calls := 0
+	err := pixfmts.RegisterConversion(pixfmts.PixFmtGray8,
+		pixfmts.PixFmtGray16LE, func(_, _ *pixfmts.Frame) error {
+			calls++
+			return errCustom
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		pixfmts.RegisterConversion(pixfmts.PixFmtGray8,
+			pixfmts.PixFmtGray16LE, nil)
+	})
+
+	dst := newFrame(t, pixfmts.PixFmtGray16LE, 4, 2)
+	if err := pixfmts.Convert(dst, src); !errors.Is(err, errCustom) ||
+		calls != 1 {
+		t.Fatalf("registered conversion not used: %v, %d calls", err, calls)
+	}
+
+	// Removing the fast path restores the generic conversion.
+	err = pixfmts.RegisterConversion(pixfmts.PixFmtGray8,
+		pixfmts.PixFmtGray16LE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pixfmts.Convert(dst, src); err != nil || calls != 1 {
+		t.Fatalf("generic conversion not used: %v, %d calls", err, calls)
+	}
+
+	if err := pixfmts.RegisterConversion(pixfmts.PixFmtVAAPI,
+		pixfmts.PixFmtNV12, nil); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	if err := pixfmts.RegisterConversion(pixfmts.PixFmtNone,
+		pixfmts.PixFmtNV12, nil); !errors.Is(err,
+		pixfmts.ErrUnknownPixelFormat) {
+		t.Fatalf("expected unknown pixel format, got %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package gopixfmts
+
+import "fmt"
+
+// AVError is a negative libavutil error code, such as AVERROR(EINVAL),
+// returned by a wrapped function. Errors carrying one wrap it alongside a
+// sentinel, so callers can use errors.As to get the code and errors.Is to
+// test for the sentinel.
+type AVError struct {
+	Code int
+}
+
+// Error returns the description av_strerror gives for the code.
+func (e *AVError) Error() string {
+	return avStrError(e.Code)
+}
+
+// avErrorf wraps sentinel and the libavutil error code in one error whose
+// message is the sentinel's, followed by the formatted details and the code's
+// description.
+func avErrorf(sentinel error, code int, format string, args ...any) error {
+	return fmt.Errorf("%w: %s: %w", sentinel, fmt.Sprintf(format, args...),
+		&AVError{Code: code})
+}
@@ -0,0 +1,38 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_Errors_Sentinels(t *testing.T) {
+	_, err := pixfmts.ColorRangeFromName("not-a-range")
+	if !errors.Is(err, pixfmts.ErrUnknownColorName) {
+		t.Fatalf("expected unknown color name, got %v", err)
+	}
+	var averr *pixfmts.AVError
+	if !errors.As(err, &averr) || averr.Code != -22 ||
+		averr.Error() != "Invalid argument" {
+		t.Fatalf("unexpected AVError %v", averr)
+	}
+
+	if _, err := pixfmts.PixFmtCountPlanes(pixfmts.PixFmtNB); !errors.Is(err,
+		pixfmts.ErrUnknownPixelFormat) || !errors.As(err, &averr) {
+		t.Fatalf("expected unknown pixel format, got %v", err)
+	}
+	if _, err := pixfmts.GetPixFmt("not-a-format"); !errors.Is(err,
+		pixfmts.ErrUnknownPixelFormat) {
+		t.Fatalf("expected unknown pixel format, got %v", err)
+	}
+	if _, err := pixfmts.PixFmtSwapEndianness(
+		pixfmts.PixFmtRGB24); !errors.Is(err, pixfmts.ErrNoEndianSwap) {
+		t.Fatalf("expected no endian swap, got %v", err)
+	}
+	if _, _, err := pixfmts.FindBestPixFmtOf2(pixfmts.PixFmtNone,
+		pixfmts.PixFmtNone, pixfmts.PixFmtRGB24, false); !errors.Is(err,
+		pixfmts.ErrNoSuitableFormat) {
+		t.Fatalf("expected no suitable format, got %v", err)
+	}
+}
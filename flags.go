@@ -0,0 +1,104 @@
+package gopixfmts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pixFmtFlagNames lists every known flag in bit order with the name used by
+// PixFmtFlag.String.
+var pixFmtFlagNames = []struct {
+	flag PixFmtFlag
+	name string
+}{
+	{PixFmtFlagBigEndian, "be"},
+	{PixFmtFlagPAL, "pal"},
+	{PixFmtFlagBitstream, "bitstream"},
+	{PixFmtFlagHWAccel, "hwaccel"},
+	{PixFmtFlagPlanar, "planar"},
+	{PixFmtFlagRGB, "rgb"},
+	{PixFmtFlagAlpha, "alpha"},
+	{PixFmtFlagBayer, "bayer"},
+	{PixFmtFlagFloat, "float"},
+	{PixFmtFlagXYZ, "xyz"},
+}
+
+// Has reports whether every bit of flag is set in f.
+func (f PixFmtFlag) Has(flag PixFmtFlag) bool {
+	return f&flag == flag
+}
+
+// String returns the set flags joined by '|', for example "planar|rgb|alpha".
+// Bits without a known name are appended in hexadecimal. An empty set is
+// reported as "none".
+func (f PixFmtFlag) String() string {
+	if f == 0 {
+		return "none"
+	}
+	var names []string
+	for _, n := range pixFmtFlagNames {
+		if f.Has(n.flag) {
+			names = append(names, n.name)
+			f &^= n.flag
+		}
+	}
+	if f != 0 {
+		names = append(names, fmt.Sprintf("%#x", uint64(f)))
+	}
+	return strings.Join(names, "|")
+}
+
+// IsPlanar reports whether at least one component is stored outside of the
+// first data plane.
+func (r *PixFmtDescRef) IsPlanar() bool { return r.Flags().Has(PixFmtFlagPlanar) }
+
+// IsRGB reports whether the format carries RGB-like data. Bayer formats are
+// RGB as well.
+func (r *PixFmtDescRef) IsRGB() bool { return r.Flags().Has(PixFmtFlagRGB) }
+
+// IsYUV reports whether the format carries luma and chroma components. Gray,
+// RGB, XYZ, paletted and hardware formats are not YUV.
+func (r *PixFmtDescRef) IsYUV() bool {
+	const notYUV = PixFmtFlagRGB | PixFmtFlagXYZ | PixFmtFlagPAL |
+		PixFmtFlagHWAccel | PixFmtFlagBayer
+	return r.Flags()&notYUV == 0 && r.NbComponents() >= 3
+}
+
+// IsGray reports whether the format carries only luma, optionally with
+// alpha. Monochrome bitstream formats are gray.
+func (r *PixFmtDescRef) IsGray() bool {
+	const notGray = PixFmtFlagRGB | PixFmtFlagXYZ | PixFmtFlagPAL |
+		PixFmtFlagHWAccel | PixFmtFlagBayer
+	n := r.NbComponents()
+	return r.Flags()&notGray == 0 && n >= 1 && n <= 2
+}
+
+// HasAlpha reports whether the format has an alpha channel.
+func (r *PixFmtDescRef) HasAlpha() bool { return r.Flags().Has(PixFmtFlagAlpha) }
+
+// IsFloat reports whether the components are IEEE-754 floating point values.
+func (r *PixFmtDescRef) IsFloat() bool { return r.Flags().Has(PixFmtFlagFloat) }
+
+// IsBigEndian reports whether multi-byte components are stored big-endian.
+func (r *PixFmtDescRef) IsBigEndian() bool {
+	return r.Flags().Has(PixFmtFlagBigEndian)
+}
+
+// IsHWAccel reports whether the format is an opaque hardware surface.
+func (r *PixFmtDescRef) IsHWAccel() bool { return r.Flags().Has(PixFmtFlagHWAccel) }
+
+// IsBayer reports whether the format follows a Bayer pattern.
+func (r *PixFmtDescRef) IsBayer() bool { return r.Flags().Has(PixFmtFlagBayer) }
+
+// IsPaletted reports whether the format stores palette indexes in data[0] and
+// the palette in data[1].
+func (r *PixFmtDescRef) IsPaletted() bool { return r.Flags().Has(PixFmtFlagPAL) }
+
+// IsBitstream reports whether component values are bit-wise packed end to
+// end.
+func (r *PixFmtDescRef) IsBitstream() bool {
+	return r.Flags().Has(PixFmtFlagBitstream)
+}
+
+// IsXYZ reports whether the format carries CIE XYZ data.
+func (r *PixFmtDescRef) IsXYZ() bool { return r.Flags().Has(PixFmtFlagXYZ) }
@@ -0,0 +1,47 @@
+package gopixfmts_test
+
+import (
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_PixFmtFlag_String(t *testing.T) {
+	gbrap, err := pixfmts.PixFmtDescGet(pixfmts.PixFmtGBRAP)
+	if err != nil {
+		t.FailNow()
+	}
+
+	if s := gbrap.Flags().String(); s != "planar|rgb|alpha" {
+		t.Fatalf("unexpected flags: %q", s)
+	}
+	if s := pixfmts.PixFmtFlag(0).String(); s != "none" {
+		t.Fatalf("unexpected flags: %q", s)
+	}
+}
+
+func Test_PixFmtDescRef_Predicates(t *testing.T) {
+	tests := []struct {
+		pf                   pixfmts.PixelFormat
+		planar, rgb, yuv, gr bool
+	}{
+		{pixfmts.PixFmtYUV420P, true, false, true, false},
+		{pixfmts.PixFmtNV12, true, false, true, false},
+		{pixfmts.PixFmtRGB24, false, true, false, false},
+		{pixfmts.PixFmtGray16BE, false, false, false, true},
+		{pixfmts.PixFmtYA8, false, false, false, true},
+		{pixfmts.PixFmtBAYER_RGGB8, false, true, false, false},
+	}
+
+	for _, tt := range tests {
+		desc, err := pixfmts.PixFmtDescGet(tt.pf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if desc.IsPlanar() != tt.planar || desc.IsRGB() != tt.rgb ||
+			desc.IsYUV() != tt.yuv || desc.IsGray() != tt.gr {
+			t.Errorf("%s: planar=%v rgb=%v yuv=%v gray=%v", desc.Name(),
+				desc.IsPlanar(), desc.IsRGB(), desc.IsYUV(), desc.IsGray())
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package gopixfmts
+
+import (
+	"fmt"
+	"math"
+)
+
+// Frame owns the planes of a single image in a given pixel format.
+//
+// A Frame bundles the data planes and linesizes that the image line functions
+// take as loose parameters, together with the format descriptor needed to
+// interpret them. Frames are not safe for concurrent mutation.
+type Frame struct {
+	format   PixelFormat
+	desc     *PixFmtDescRef
+	width    int
+	height   int
+	data     [4][]byte
+	linesize [4]int
+}
+
+// NewFrame allocates a zeroed frame of the given pixel format and size.
+//
+// Every linesize is rounded up to a multiple of align, which should be a
+// power of two such as 1, 16, 32 or 64. Plane sizes are derived from the
+// format descriptor's chroma subsampling and component steps, so odd widths
+// and heights round the chroma planes up. All planes share a single backing
+// allocation. Paletted formats get a PaletteSiz byte palette in plane 1.
+//
+// Hardware accelerated formats, dimensions that are not positive and images
+// too large to address, as checked by av_image_check_size, return
+// ErrInvalidArgument. Unknown formats return ErrUnknownPixelFormat.
+func NewFrame(pf PixelFormat, width, height, align int) (*Frame, error) {
+	desc, err := PixFmtDescGet(pf)
+	if err != nil {
+		return nil, err
+	}
+	if align < 0 {
+		return nil, ErrInvalidArgument
+	}
+	if err := checkImageSize(width, height); err != nil {
+		return nil, err
+	}
+
+	// Like av_image_alloc, pad the width for SIMD friendly strides.
+	w := width
+	if align > 7 {
+		w = alignUp(width, 8)
+	}
+	linesize, err := desc.FillLinesizes(w, align)
+	if err != nil {
+		return nil, err
+	}
+	sizes, err := fillPlaneSizes(desc, height, linesize)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, s := range sizes {
+		if s > math.MaxInt-total {
+			return nil, ErrInvalidArgument
+		}
+		total += s
+	}
+	buf := make([]byte, total)
+
+	f := &Frame{format: pf, desc: desc, width: width, height: height,
+		linesize: linesize}
+	off := 0
+	for i, s := range sizes {
+		if s == 0 {
+			continue
+		}
+		f.data[i] = buf[off : off+s : off+s]
+		off += s
+	}
+	return f, nil
+}
+
+// checkImageSize verifies that a width x height image is small enough to be
+// handled safely, following av_image_check_size: both dimensions must be
+// positive and (width+128)*(height+128) must stay below INT_MAX/8.
+func checkImageSize(width, height int) error {
+	if width <= 0 || height <= 0 || width > math.MaxInt32 ||
+		height > math.MaxInt32 ||
+		uint64(width+128)*uint64(height+128) >= math.MaxInt32/8 {
+		return fmt.Errorf("%w: image size %dx%d", ErrInvalidArgument, width,
+			height)
+	}
+	return nil
+}
+
+// NewFrameFromPlanes wraps existing plane buffers in a Frame without copying
+// them.
+//
+// Each plane used by the format must hold at least as many bytes as the
+// image needs given the supplied linesizes, otherwise a *BoundsError is
+// returned. Sizes NewFrame rejects, including empty ones, and linesizes
+// smaller than the minimum for the width return ErrInvalidArgument.
+func NewFrameFromPlanes(pf PixelFormat, width, height int, data [4][]byte,
+	linesize [4]int) (*Frame, error) {
+	desc, err := PixFmtDescGet(pf)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImageSize(width, height); err != nil {
+		return nil, err
+	}
+
+	minLinesize, err := fillLinesizes(desc, width)
+	if err != nil {
+		return nil, err
+	}
+	for i := range linesize {
+		if linesize[i] < minLinesize[i] {
+			return nil, ErrInvalidArgument
+		}
+	}
+	sizes, err := fillPlaneSizes(desc, height, linesize)
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range sizes {
+		if len(data[i]) < s {
+			return nil, &BoundsError{Plane: i, Start: 0, End: s,
+				Len: len(data[i])}
+		}
+	}
+
+	return &Frame{format: pf, desc: desc, width: width, height: height,
+		data: data, linesize: linesize}, nil
+}
+
+// Format returns the pixel format of the frame.
+func (f *Frame) Format() PixelFormat { return f.format }
+
+// Desc returns the descriptor of the frame's pixel format.
+func (f *Frame) Desc() *PixFmtDescRef { return f.desc }
+
+// Width returns the width of the frame in pixels.
+func (f *Frame) Width() int { return f.width }
+
+// Height returns the height of the frame in pixels.
+func (f *Frame) Height() int { return f.height }
+
+// Planes returns the data planes of the frame. The slices alias the frame's
+// memory; unused planes are nil.
+func (f *Frame) Planes() [4][]byte { return f.data }
+
+// Linesizes returns the stride in bytes of every plane. Unused planes report
+// 0.
+func (f *Frame) Linesizes() [4]int { return f.linesize }
+
+// PlaneSize returns the width and height in samples of the given plane.
+//
+// Planes 1 and 2 are reduced by the chroma subsampling factors, rounding up
+// for odd dimensions. Planes that the format does not use, and paletted
+// formats' palette plane, report 0x0.
+func (f *Frame) PlaneSize(plane int) (width, height int) {
+	return f.desc.PlaneWidth(plane, f.width),
+		f.desc.PlaneHeight(plane, f.height)
+}
+
+// ReadImageLine reads w samples of component c starting at (x, y) into dst.
+// It is ReadImageLineNative applied to the frame's planes, so no cgo call is
+// made.
+func (f *Frame) ReadImageLine(dst []uint16, x, y, c, w int,
+	readPalComponent bool) error {
+	return ReadImageLineNative(dst, f.data, f.linesize, f.desc, x, y, c, w,
+		readPalComponent)
+}
+
+// ReadImageLine2 reads w samples of component c starting at (x, y) into dst
+// using elements of dstElementSize bytes. It is ReadImageLine2Native applied
+// to the frame's planes.
+func (f *Frame) ReadImageLine2(dst []byte, x, y, c, w int,
+	readPalComponent bool, dstElementSize int) error {
+	return ReadImageLine2Native(dst, f.data, f.linesize, f.desc, x, y, c, w,
+		readPalComponent, dstElementSize)
+}
+
+// WriteImageLine writes w samples of component c from src starting at
+// (x, y). It is WriteImageLineNative applied to the frame's planes.
+func (f *Frame) WriteImageLine(src []uint16, x, y, c, w int) error {
+	return WriteImageLineNative(src, f.data, f.linesize, f.desc, x, y, c, w)
+}
+
+// WriteImageLine2 writes w samples of component c from src, whose elements
+// are srcElementSize bytes, starting at (x, y). It is WriteImageLine2Native
+// applied to the frame's planes.
+func (f *Frame) WriteImageLine2(src []byte, x, y, c, w int,
+	srcElementSize int) error {
+	return WriteImageLine2Native(src, f.data, f.linesize, f.desc, x, y, c, w,
+		srcElementSize)
+}
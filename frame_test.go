@@ -0,0 +1,74 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_NewFrame(t *testing.T) {
+	frame, err := pixfmts.NewFrame(pixfmts.PixFmtYUV420P10LE, 1921, 1081, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linesize := frame.Linesizes()
+	if linesize != [4]int{3872, 1952, 1952, 0} {
+		t.Fatalf("unexpected linesizes: %v", linesize)
+	}
+
+	planes := frame.Planes()
+	if len(planes[0]) != 3872*1081 || len(planes[1]) != 1952*541 ||
+		planes[3] != nil {
+		t.Fatalf("unexpected plane sizes: %d %d %d %d", len(planes[0]),
+			len(planes[1]), len(planes[2]), len(planes[3]))
+	}
+
+	if w, h := frame.PlaneSize(2); w != 961 || h != 541 {
+		t.Fatalf("unexpected chroma plane size: %dx%d", w, h)
+	}
+
+	for _, size := range [][2]int{{0, 4}, {65535, 65535}, {3000000000, 1}} {
+		if _, err := pixfmts.NewFrame(pixfmts.PixFmtYUV420P, size[0],
+			size[1], 1); !errors.Is(err, pixfmts.ErrInvalidArgument) {
+			t.Fatalf("%dx%d: expected invalid argument, got %v", size[0],
+				size[1], err)
+		}
+	}
+}
+
+func Test_Frame_WriteReadImageLine(t *testing.T) {
+	frame, err := pixfmts.NewFrame(pixfmts.PixFmtNV12, 7, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, _ := frame.PlaneSize(1)
+	src := []uint16{1, 2, 3, 4}
+	if err := frame.WriteImageLine(src, 0, 1, 2, w); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := make([]uint16, w)
+	if err := frame.ReadImageLine(dst, 0, 1, 2, w, false); err != nil {
+		t.Fatal(err)
+	}
+	for i := range src {
+		if dst[i] != src[i] {
+			t.Fatalf("sample %d: got %d want %d", i, dst[i], src[i])
+		}
+	}
+}
+
+func Test_NewFrameFromPlanes_Size(t *testing.T) {
+	for _, size := range [][2]int{{0, 0}, {0, 4}, {65535, 65535},
+		{3000000000, 1}} {
+		_, err := pixfmts.NewFrameFromPlanes(pixfmts.PixFmtGray8, size[0],
+			size[1], [4][]byte{}, [4]int{size[0]})
+		if !errors.Is(err, pixfmts.ErrInvalidArgument) {
+			t.Fatalf("%dx%d: expected invalid argument, got %v", size[0],
+				size[1], err)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+//go:build ignore
+
+// gen_consts mirrors the libavutil backed constants of pixfmt.go and
+// pixdesc.go into zpixfmt_nocgo.go so the package can be built without cgo.
+//
+// Every type declaration that does not depend on C and every constant
+// declaration that does is copied verbatim, with the C values replaced by
+// the numbers the Go type checker resolved them to. It must be run with cgo
+// enabled and the libavutil headers installed:
+//
+//	go run gen_consts.go
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"sort"
+)
+
+var sources = []string{"pixfmt.go", "pixdesc.go"}
+
+const output = "zpixfmt_nocgo.go"
+
+func main() {
+	wd, err := os.Getwd()
+	if err != nil {
+		log.Fatal(err)
+	}
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil)
+	pkg, err := imp.(types.ImporterFrom).ImportFrom(".", wd, 0)
+	if err != nil {
+		log.Fatalf("type checking package: %v", err)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by gen_consts.go; DO NOT EDIT.\n\n")
+	out.WriteString("//go:build !cgo\n\npackage gopixfmts\n")
+
+	for _, name := range sources {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || !mirrored(gen) {
+				continue
+			}
+			out.WriteString("\n")
+			out.Write(rewriteDecl(fset, src, gen, pkg))
+			out.WriteString("\n")
+		}
+	}
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("formatting output: %v", err)
+	}
+	if err := os.WriteFile(output, formatted, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// mirrored reports whether a declaration belongs in the cgo-free mirror.
+func mirrored(gen *ast.GenDecl) bool {
+	switch gen.Tok {
+	case token.TYPE:
+		return !usesC(gen)
+	case token.CONST:
+		return usesC(gen)
+	}
+	return false
+}
+
+// usesC reports whether node references the pseudo package C.
+func usesC(node ast.Node) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Name == "C" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// rewriteDecl returns the source of gen, including its doc comment, with
+// every constant value that references C replaced by its resolved value.
+func rewriteDecl(fset *token.FileSet, src []byte, gen *ast.GenDecl,
+	pkg *types.Package) []byte {
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+	for _, spec := range gen.Specs {
+		vs, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for i, value := range vs.Values {
+			if !usesC(value) {
+				continue
+			}
+			obj, ok := pkg.Scope().Lookup(vs.Names[i].Name).(*types.Const)
+			if !ok {
+				log.Fatalf("%s is not a constant", vs.Names[i].Name)
+			}
+			edits = append(edits, edit{
+				start: fset.Position(value.Pos()).Offset,
+				end:   fset.Position(value.End()).Offset,
+				text:  obj.Val().ExactString(),
+			})
+		}
+	}
+
+	start := gen.Pos()
+	if gen.Doc != nil {
+		start = gen.Doc.Pos()
+	}
+	lo, hi := fset.Position(start).Offset, fset.Position(gen.End()).Offset
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	text := append([]byte(nil), src[lo:hi]...)
+	for _, e := range edits {
+		tail := append([]byte(e.text), text[e.end-lo:]...)
+		text = append(text[:e.start-lo], tail...)
+	}
+	return text
+}
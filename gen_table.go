@@ -0,0 +1,139 @@
+//go:build ignore
+
+// gen_table dumps libavutil's pixel format descriptors and color property
+// names into zpixdesc_table.go, which backs the cgo-free build of the
+// package and is checked against libavutil by the cgo tests.
+//
+// It must be run with cgo enabled against the libavutil version the package
+// targets:
+//
+//	go run gen_table.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+const output = "zpixdesc_table.go"
+
+// flagNames maps every descriptor flag to the Go constant that spells it.
+var flagNames = []struct {
+	flag pixfmts.PixFmtFlag
+	name string
+}{
+	{pixfmts.PixFmtFlagBigEndian, "PixFmtFlagBigEndian"},
+	{pixfmts.PixFmtFlagPAL, "PixFmtFlagPAL"},
+	{pixfmts.PixFmtFlagBitstream, "PixFmtFlagBitstream"},
+	{pixfmts.PixFmtFlagHWAccel, "PixFmtFlagHWAccel"},
+	{pixfmts.PixFmtFlagPlanar, "PixFmtFlagPlanar"},
+	{pixfmts.PixFmtFlagRGB, "PixFmtFlagRGB"},
+	{pixfmts.PixFmtFlagAlpha, "PixFmtFlagAlpha"},
+	{pixfmts.PixFmtFlagBayer, "PixFmtFlagBayer"},
+	{pixfmts.PixFmtFlagFloat, "PixFmtFlagFloat"},
+	{pixfmts.PixFmtFlagXYZ, "PixFmtFlagXYZ"},
+}
+
+func main() {
+	var out bytes.Buffer
+	out.WriteString("// Code generated by gen_table.go; DO NOT EDIT.\n\n")
+	out.WriteString("package gopixfmts\n\n")
+
+	out.WriteString("// pixFmtDescriptors mirrors libavutil's descriptor table and is indexed\n")
+	out.WriteString("// by PixelFormat.\n")
+	out.WriteString("var pixFmtDescriptors = [...]pixFmtDescriptor{\n")
+	for pf := pixfmts.PixelFormat(0); pf < pixfmts.PixFmtNB; pf++ {
+		desc, err := pixfmts.PixFmtDescGet(pf)
+		if err != nil {
+			log.Fatalf("pixel format %d: %v", pf, err)
+		}
+		writeDescriptor(&out, desc)
+	}
+	out.WriteString("}\n")
+
+	writeNames(&out, "colorRangeNames", pixfmts.ColorRangeName,
+		len(slices.Collect(pixfmts.AllColorRanges())))
+	writeNames(&out, "colorPrimariesNames", pixfmts.ColorPrimariesName,
+		int(pixfmts.ColorPrimariesNB))
+	writeNames(&out, "colorTransferNames", pixfmts.ColorTransferName,
+		int(pixfmts.ColorTransferCharacteristicNB))
+	writeNames(&out, "colorSpaceNames", pixfmts.ColorSpaceName,
+		int(pixfmts.ColorSpaceNB))
+	writeNames(&out, "chromaLocationNames", pixfmts.ChromaLocationName,
+		int(pixfmts.ChromaLocationNB))
+
+	formatted, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("formatting output: %v", err)
+	}
+	if err := os.WriteFile(output, formatted, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeDescriptor(out *bytes.Buffer, desc *pixfmts.PixFmtDescRef) {
+	fmt.Fprintf(out, "\t{\n\t\tname: %q,", desc.Name())
+	if alias := desc.Alias(); alias != "" {
+		fmt.Fprintf(out, " alias: %q,", alias)
+	}
+	if n := desc.NbComponents(); n != 0 {
+		fmt.Fprintf(out, " nbComponents: %d,", n)
+	}
+	if w := desc.Log2ChromaW(); w != 0 {
+		fmt.Fprintf(out, " log2ChromaW: %d,", w)
+	}
+	if h := desc.Log2ChromaH(); h != 0 {
+		fmt.Fprintf(out, " log2ChromaH: %d,", h)
+	}
+	out.WriteString("\n")
+
+	if flags := desc.Flags(); flags != 0 {
+		var names []string
+		for _, f := range flagNames {
+			if flags.Has(f.flag) {
+				names = append(names, f.name)
+				flags &^= f.flag
+			}
+		}
+		if flags != 0 {
+			log.Fatalf("%s: unknown flags %#x", desc.Name(), uint64(flags))
+		}
+		fmt.Fprintf(out, "\t\tflags: %s,\n", strings.Join(names, " | "))
+	}
+
+	var comps []string
+	for i := 0; i < 4; i++ {
+		c, err := desc.Component(i)
+		if err != nil {
+			log.Fatal(err)
+		}
+		comps = append(comps, fmt.Sprintf("{%d, %d, %d, %d, %d}",
+			c.Plane, c.Step, c.Offset, c.Shift, c.Depth))
+	}
+	for len(comps) > 0 && comps[len(comps)-1] == "{0, 0, 0, 0, 0}" {
+		comps = comps[:len(comps)-1]
+	}
+	if len(comps) > 0 {
+		fmt.Fprintf(out, "\t\tcomp: [4]ComponentDescriptor{%s},\n",
+			strings.Join(comps, ", "))
+	}
+	out.WriteString("\t},\n")
+}
+
+func writeNames(out *bytes.Buffer, table string, name func(int) string,
+	n int) {
+	fmt.Fprintf(out, "\nvar %s = [...]string{\n", table)
+	for v := 0; v < n; v++ {
+		if s := name(v); s != "" {
+			fmt.Fprintf(out, "\t%d: %q,\n", v, s)
+		}
+	}
+	out.WriteString("}\n")
+}
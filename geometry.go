@@ -0,0 +1,99 @@
+package gopixfmts
+
+// ---------------- Plane geometry ----------------
+
+// PlaneWidth returns the width in samples of the given plane for an image
+// that is width pixels wide.
+//
+// Planes 1 and 2 are reduced by the horizontal chroma subsampling factor,
+// rounding up, so plane 1 of a 1921 pixel wide yuv420p image is 961 samples
+// wide. Planes that the format does not use, the palette plane of paletted
+// formats and negative widths report 0.
+func (r *PixFmtDescRef) PlaneWidth(plane, width int) int {
+	if r == nil || width < 0 || plane < 0 || plane >= 4 ||
+		!hasPlanes(r)[plane] {
+		return 0
+	}
+	sw, _ := planeShifts(r, plane)
+	return shiftCeil(width, sw)
+}
+
+// PlaneHeight returns the height in rows of the given plane for an image
+// that is height pixels tall.
+//
+// Planes 1 and 2 are reduced by the vertical chroma subsampling factor,
+// rounding up. Planes that the format does not use, the palette plane of
+// paletted formats and negative heights report 0.
+func (r *PixFmtDescRef) PlaneHeight(plane, height int) int {
+	if r == nil || height < 0 || plane < 0 || plane >= 4 ||
+		!hasPlanes(r)[plane] {
+		return 0
+	}
+	_, sh := planeShifts(r, plane)
+	return shiftCeil(height, sh)
+}
+
+// MinLinesize returns the smallest number of bytes a line of the given plane
+// can take for an image that is width pixels wide, following
+// av_image_fill_linesizes.
+//
+// The size is derived from the largest component step stored in the plane.
+// Bitstream formats round the bits of a line up to whole bytes. The palette
+// of paletted formats is not addressed by lines and reports 0, like unused
+// planes do.
+//
+// Hardware accelerated formats, negative widths and plane indexes outside
+// 0..3 return ErrInvalidArgument.
+func (r *PixFmtDescRef) MinLinesize(plane, width int) (int, error) {
+	if plane < 0 || plane >= 4 {
+		return 0, ErrInvalidArgument
+	}
+	linesize, err := fillLinesizes(r, width)
+	if err != nil {
+		return 0, err
+	}
+	return linesize[plane], nil
+}
+
+// FillLinesizes returns the linesize of every plane for an image that is
+// width pixels wide, with each linesize rounded up to a multiple of align.
+//
+// An align of 0 or 1 yields the minimal linesizes reported by MinLinesize.
+// Unused planes report 0. Hardware accelerated formats and negative
+// arguments return ErrInvalidArgument.
+func (r *PixFmtDescRef) FillLinesizes(width, align int) ([4]int, error) {
+	if align < 0 {
+		return [4]int{}, ErrInvalidArgument
+	}
+	linesize, err := fillLinesizes(r, width)
+	if err != nil {
+		return linesize, err
+	}
+	for i := range linesize {
+		linesize[i] = alignUp(linesize[i], align)
+	}
+	return linesize, nil
+}
+
+// ImageSize returns the number of bytes needed to store a width x height
+// image whose linesizes are aligned to align, following
+// av_image_get_buffer_size.
+//
+// The size covers every plane laid out back to back, including the
+// PaletteSiz byte palette of paletted formats. Hardware accelerated formats
+// and negative arguments return ErrInvalidArgument.
+func (r *PixFmtDescRef) ImageSize(width, height, align int) (int, error) {
+	linesize, err := r.FillLinesizes(width, align)
+	if err != nil {
+		return 0, err
+	}
+	sizes, err := fillPlaneSizes(r, height, linesize)
+	if err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, s := range sizes {
+		total += s
+	}
+	return total, nil
+}
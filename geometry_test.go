@@ -0,0 +1,70 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_PlaneGeometry(t *testing.T) {
+	yuv420p10le, err := pixfmts.PixFmtDescGet(pixfmts.PixFmtYUV420P10LE)
+	if err != nil {
+		t.FailNow()
+	}
+
+	if w := yuv420p10le.PlaneWidth(1, 1921); w != 961 {
+		t.Fatalf("unexpected chroma width: %d", w)
+	}
+	if h := yuv420p10le.PlaneHeight(2, 1081); h != 541 {
+		t.Fatalf("unexpected chroma height: %d", h)
+	}
+	if w := yuv420p10le.PlaneWidth(3, 1921); w != 0 {
+		t.Fatalf("unused plane has width %d", w)
+	}
+
+	if n, err := yuv420p10le.MinLinesize(1, 1921); err != nil || n != 1922 {
+		t.Fatalf("unexpected chroma linesize: %d, %v", n, err)
+	}
+	linesize, err := yuv420p10le.FillLinesizes(1921, 32)
+	if err != nil || linesize != [4]int{3872, 1952, 1952, 0} {
+		t.Fatalf("unexpected linesizes: %v, %v", linesize, err)
+	}
+	size, err := yuv420p10le.ImageSize(1921, 1081, 32)
+	if err != nil || size != 3872*1081+2*1952*541 {
+		t.Fatalf("unexpected image size: %d, %v", size, err)
+	}
+
+	nv12, _ := pixfmts.PixFmtDescGet(pixfmts.PixFmtNV12)
+	if n, _ := nv12.MinLinesize(1, 7); n != 8 {
+		t.Fatalf("unexpected interleaved chroma linesize: %d", n)
+	}
+
+	monob, _ := pixfmts.PixFmtDescGet(pixfmts.PixFmtMonoBlack)
+	if n, _ := monob.MinLinesize(0, 10); n != 2 {
+		t.Fatalf("unexpected bitstream linesize: %d", n)
+	}
+
+	pal8, _ := pixfmts.PixFmtDescGet(pixfmts.PixFmtPal8)
+	if w := pal8.PlaneWidth(1, 4); w != 0 {
+		t.Fatalf("palette plane has width %d", w)
+	}
+	if size, _ := pal8.ImageSize(4, 2, 1); size != 8+pixfmts.PaletteSiz {
+		t.Fatalf("unexpected paletted image size: %d", size)
+	}
+
+	if _, err := yuv420p10le.MinLinesize(4, 16); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+
+	// Sizes that overflow an int are rejected instead of wrapping.
+	if _, err := yuv420p10le.ImageSize(1<<20, 1<<50, 1); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	if _, err := yuv420p10le.ImageSize(3000000000, 1, 1); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
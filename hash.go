@@ -0,0 +1,161 @@
+package gopixfmts
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/fnv"
+)
+
+// HashAlgorithm selects the digest computed by HashFrame.
+type HashAlgorithm int
+
+const (
+	HashMD5     HashAlgorithm = iota // MD5, as FFmpeg's framemd5 muxer.
+	HashAdler32                      // Adler-32 seeded with 0, as FFmpeg's framecrc muxer.
+	HashCRC32                        // CRC-32 with the IEEE polynomial.
+	HashFNV64a                       // 64-bit FNV-1a, a fast non-cryptographic hash.
+)
+
+// newHash returns a fresh hash.Hash for the algorithm.
+func (a HashAlgorithm) newHash() (hash.Hash, error) {
+	switch a {
+	case HashMD5:
+		return md5.New(), nil
+	case HashAdler32:
+		return &adler32Zero{}, nil
+	case HashCRC32:
+		return crc32.NewIEEE(), nil
+	case HashFNV64a:
+		return fnv.New64a(), nil
+	}
+	return nil, fmt.Errorf("%w: hash algorithm %d", ErrInvalidArgument, int(a))
+}
+
+// adlerMod is the modulus of Adler-32, and adlerNMax the number of bytes
+// that can be summed before the sums must be reduced to avoid overflow.
+const (
+	adlerMod  = 65521
+	adlerNMax = 5552
+)
+
+// adler32Zero is Adler-32 started from 0 rather than the standard seed of
+// 1, which is what av_adler32_update(0, ...) in FFmpeg's framecrc muxer
+// computes. hash/adler32 has no way to change the seed.
+type adler32Zero struct{ s1, s2 uint32 }
+
+func (d *adler32Zero) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		chunk := p[:min(len(p), adlerNMax)]
+		for _, b := range chunk {
+			d.s1 += uint32(b)
+			d.s2 += d.s1
+		}
+		d.s1 %= adlerMod
+		d.s2 %= adlerMod
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+func (d *adler32Zero) Sum32() uint32 { return d.s2<<16 | d.s1 }
+
+func (d *adler32Zero) Sum(b []byte) []byte {
+	return binary.BigEndian.AppendUint32(b, d.Sum32())
+}
+
+func (d *adler32Zero) Reset()         { *d = adler32Zero{} }
+func (d *adler32Zero) Size() int      { return 4 }
+func (d *adler32Zero) BlockSize() int { return 4 }
+
+// HashOptions tunes HashFrame.
+type HashOptions struct {
+	Algorithm HashAlgorithm
+
+	// PerComponent additionally hashes every component on its own, with
+	// samples widened to 16 bits, or 32 bits for deeper components, and
+	// stored little-endian. These digests do not depend on the packing,
+	// plane layout or endianness of the format.
+	PerComponent bool
+}
+
+// FrameHash holds the digests computed by HashFrame.
+type FrameHash struct {
+	// Sum covers the meaningful bytes of every plane in order, excluding
+	// linesize padding, followed by the palette of paletted formats. With
+	// HashMD5 and HashAdler32 it matches FFmpeg's framemd5 and framecrc.
+	Sum []byte
+
+	// Components holds one digest per component when
+	// HashOptions.PerComponent is set.
+	Components [][]byte
+}
+
+// String returns Sum in hexadecimal.
+func (h *FrameHash) String() string { return hex.EncodeToString(h.Sum) }
+
+// HashFrame hashes an image given as loose data planes and linesizes.
+//
+// Only the bytes that carry pixels are hashed, so two buffers holding the
+// same image with different linesizes hash the same. Planes must be large
+// enough for the linesizes, as for NewFrameFromPlanes. A nil opts hashes
+// with MD5. Unknown algorithms, hardware accelerated formats and empty
+// images return ErrInvalidArgument.
+func HashFrame(pf PixelFormat, width, height int, data [4][]byte,
+	linesize [4]int, opts *HashOptions) (*FrameHash, error) {
+	f, err := NewFrameFromPlanes(pf, width, height, data, linesize)
+	if err != nil {
+		return nil, err
+	}
+	return f.Hash(opts)
+}
+
+// Hash hashes the frame like HashFrame.
+func (f *Frame) Hash(opts *HashOptions) (*FrameHash, error) {
+	if opts == nil {
+		opts = &HashOptions{}
+	}
+	h, err := opts.Algorithm.newHash()
+	if err != nil {
+		return nil, err
+	}
+	layout, err := newRawLayout(f.format, f.width, f.height)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, layout.size)
+	layout.pack(buf, f)
+	h.Write(buf)
+	out := &FrameHash{Sum: h.Sum(nil)}
+	if !opts.PerComponent {
+		return out, nil
+	}
+
+	for c := 0; c < f.desc.NbComponents(); c++ {
+		s, err := ReadComponent32(f, c)
+		if err != nil {
+			return nil, err
+		}
+		comp, _ := f.desc.Component(c)
+		size := 2
+		if comp.Depth > 16 {
+			size = 4
+		}
+		b := make([]byte, len(s.Samples)*size)
+		for i, v := range s.Samples {
+			if size == 2 {
+				binary.LittleEndian.PutUint16(b[2*i:], uint16(v))
+			} else {
+				binary.LittleEndian.PutUint32(b[4*i:], v)
+			}
+		}
+		h.Reset()
+		h.Write(b)
+		out.Components = append(out.Components, h.Sum(nil))
+	}
+	return out, nil
+}
@@ -0,0 +1,120 @@
+package gopixfmts_test
+
+import (
+	"bytes"
+	"crypto/md5"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_HashFrame_IgnoresPadding(t *testing.T) {
+	tight, err := pixfmts.NewFrame(pixfmts.PixFmtYUV420P, 5, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(10))
+	for _, p := range tight.Planes() {
+		rng.Read(p)
+	}
+	padded, err := pixfmts.NewFrame(pixfmts.PixFmtYUV420P, 5, 3, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range padded.Planes() {
+		rng.Read(p)
+	}
+	if err := pixfmts.Convert(padded, tight); err != nil {
+		t.Fatal(err)
+	}
+
+	var raw []byte
+	for _, p := range tight.Planes() {
+		raw = append(raw, p...)
+	}
+	want := md5.Sum(raw)
+	for _, f := range []*pixfmts.Frame{tight, padded} {
+		h, err := pixfmts.HashFrame(f.Format(), f.Width(), f.Height(),
+			f.Planes(), f.Linesizes(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(h.Sum, want[:]) {
+			t.Fatalf("got %s, want %x", h, want)
+		}
+	}
+
+	if _, err := tight.Hash(&pixfmts.HashOptions{
+		Algorithm: -1}); !errors.Is(err, pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
+
+func Test_HashFrame_PerComponent(t *testing.T) {
+	le := newFrame(t, pixfmts.PixFmtYUV420P10LE, 6, 4)
+	rng := rand.New(rand.NewSource(11))
+	for c := range 3 {
+		s, _ := pixfmts.ReadComponent(le, c)
+		for i := range s.Samples {
+			s.Samples[i] = uint16(rng.Intn(1024))
+		}
+		if err := pixfmts.WriteComponent(le, c, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	be := newFrame(t, pixfmts.PixFmtYUV420P10BE, 6, 4)
+	if err := pixfmts.Convert(be, le); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &pixfmts.HashOptions{Algorithm: pixfmts.HashFNV64a,
+		PerComponent: true}
+	a, err := le.Hash(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := be.Hash(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(a.Sum, b.Sum) {
+		t.Fatal("byte hashes of different endianness match")
+	}
+	if len(a.Components) != 3 || !slices.EqualFunc(a.Components,
+		b.Components, bytes.Equal) {
+		t.Fatalf("component hashes differ: %x, %x", a.Components,
+			b.Components)
+	}
+}
+
+func Test_HashFrame_Adler32(t *testing.T) {
+	// framecrc seeds Adler-32 with 0, so "abc" sums to 0x024a0126 rather
+	// than the 0x024d0127 of hash/adler32. The larger frame crosses the
+	// 5552 byte reduction interval; its sum is zlib.adler32(data, 0).
+	large := make([]byte, 128*96)
+	for i := range large {
+		large[i] = byte(i*7 + 3)
+	}
+	tests := []struct {
+		w, h int
+		data []byte
+		want string
+	}{{3, 1, []byte("abc"), "024a0126"}, {128, 96, large, "0927e959"}}
+	for _, tc := range tests {
+		f := newFrame(t, pixfmts.PixFmtGray8, tc.w, tc.h)
+		for y := range tc.h {
+			copy(f.Planes()[0][y*f.Linesizes()[0]:], tc.data[y*tc.w:][:tc.w])
+		}
+		h, err := f.Hash(&pixfmts.HashOptions{
+			Algorithm: pixfmts.HashAdler32})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h.String() != tc.want {
+			t.Fatalf("%dx%d: got %s, want %s", tc.w, tc.h, h, tc.want)
+		}
+	}
+}
@@ -0,0 +1,377 @@
+package gopixfmts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"math"
+)
+
+// imageKind selects how the components of a frame map to a color.Color.
+type imageKind int
+
+const (
+	imageYCbCr imageKind = iota
+	imageGray
+	imageRGB
+	imagePaletted
+)
+
+// Image adapts a Frame to image.Image and draw.Image.
+//
+// YUV formats are exposed as color.YCbCr, or color.NYCbCrA when they carry
+// alpha, with every component scaled to 8 bits. The Y'CbCr values are passed
+// through untouched, so the standard library interprets them as full range
+// BT.601. Gray formats are exposed as color.Gray16, RGB formats and gray
+// formats with alpha as color.RGBA64, and paletted formats resolve their
+// palette to color.RGBA64. Alpha is stored straight in frames and is
+// premultiplied on the way out.
+//
+// Set writes through to the frame's planes. On chroma subsampled formats the
+// chroma of a pixel is shared with its neighbours, so the last Set wins.
+// Paletted frames always use their current palette, even after it was edited
+// through Frame.Planes.
+type Image struct {
+	frame *Frame
+	kind  imageKind
+	alpha bool
+	model color.Model
+
+	// pal caches the palette of paletted frames for Set. palRaw holds the
+	// palette bytes it was built from.
+	pal    color.Palette
+	palRaw []byte
+}
+
+// NewImage returns an Image backed by the planes of f.
+//
+// Hardware accelerated, Bayer and XYZ formats have no meaningful mapping to
+// the standard color models and return ErrInvalidArgument.
+func NewImage(f *Frame) (*Image, error) {
+	if f == nil {
+		return nil, ErrInvalidArgument
+	}
+	kind, err := imageKindOf(f.desc)
+	if err != nil {
+		return nil, err
+	}
+	img := &Image{frame: f, kind: kind, alpha: f.desc.HasAlpha(),
+		model: color.RGBA64Model}
+	switch {
+	case kind == imageGray && !img.alpha:
+		img.model = color.Gray16Model
+	case kind == imageYCbCr && img.alpha:
+		img.model = color.NYCbCrAModel
+	case kind == imageYCbCr:
+		img.model = color.YCbCrModel
+	}
+	return img, nil
+}
+
+// imageKindOf classifies desc for conversions to and from the standard
+// color models. Hardware accelerated, Bayer and XYZ formats return
+// ErrInvalidArgument.
+func imageKindOf(desc *PixFmtDescRef) (imageKind, error) {
+	switch {
+	case desc.IsPaletted():
+		return imagePaletted, nil
+	case desc.IsGray():
+		return imageGray, nil
+	case desc.IsRGB() && !desc.IsBayer():
+		return imageRGB, nil
+	case desc.IsYUV():
+		return imageYCbCr, nil
+	}
+	return 0, ErrInvalidArgument
+}
+
+// Frame returns the frame backing the image.
+func (img *Image) Frame() *Frame { return img.frame }
+
+// ColorModel returns the color model matching the frame's format.
+func (img *Image) ColorModel() color.Model { return img.model }
+
+// Bounds returns the frame rectangle, anchored at the origin.
+func (img *Image) Bounds() image.Rectangle {
+	return image.Rect(0, 0, img.frame.width, img.frame.height)
+}
+
+// At returns the color of the pixel at (x, y). Pixels outside of the bounds
+// are transparent black, or black for formats without alpha.
+func (img *Image) At(x, y int) color.Color {
+	if !image.Pt(x, y).In(img.Bounds()) {
+		return img.model.Convert(color.Transparent)
+	}
+	alpha := img.frame.desc.NbComponents() - 1
+
+	switch img.kind {
+	case imageYCbCr:
+		ycc := color.YCbCr{Y: img.sample8(0, x, y), Cb: img.sample8(1, x, y),
+			Cr: img.sample8(2, x, y)}
+		if img.alpha {
+			return color.NYCbCrA{YCbCr: ycc, A: img.sample8(alpha, x, y)}
+		}
+		return ycc
+	case imageGray:
+		v := img.sample16(0, x, y)
+		if img.frame.format == PixFmtMonoWhite {
+			v = ^v
+		}
+		if img.alpha {
+			return premultiply(v, v, v, img.sample16(alpha, x, y))
+		}
+		return color.Gray16{Y: v}
+	case imageRGB:
+		r, g, b := img.sample16(0, x, y), img.sample16(1, x, y),
+			img.sample16(2, x, y)
+		a := uint16(0xffff)
+		if img.alpha {
+			a = img.sample16(alpha, x, y)
+		}
+		return premultiply(r, g, b, a)
+	default:
+		return img.paletteEntry(img.raw(0, x, y))
+	}
+}
+
+// Set stores c at (x, y), converting it to the frame's format. Points outside
+// of the bounds are ignored.
+func (img *Image) Set(x, y int, c color.Color) {
+	if !image.Pt(x, y).In(img.Bounds()) {
+		return
+	}
+	alpha := img.frame.desc.NbComponents() - 1
+
+	switch img.kind {
+	case imageYCbCr:
+		if img.alpha {
+			n := color.NYCbCrAModel.Convert(c).(color.NYCbCrA)
+			img.store8(0, x, y, n.Y)
+			img.store8(1, x, y, n.Cb)
+			img.store8(2, x, y, n.Cr)
+			img.store8(alpha, x, y, n.A)
+			return
+		}
+		ycc := color.YCbCrModel.Convert(c).(color.YCbCr)
+		img.store8(0, x, y, ycc.Y)
+		img.store8(1, x, y, ycc.Cb)
+		img.store8(2, x, y, ycc.Cr)
+	case imageGray:
+		n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+		opaque := color.NRGBA64{R: n.R, G: n.G, B: n.B, A: 0xffff}
+		v := color.Gray16Model.Convert(opaque).(color.Gray16).Y
+		if img.frame.format == PixFmtMonoWhite {
+			v = ^v
+		}
+		img.store16(0, x, y, v)
+		if img.alpha {
+			img.store16(alpha, x, y, n.A)
+		}
+	case imageRGB:
+		var r, g, b uint16
+		if img.alpha {
+			n := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+			r, g, b = n.R, n.G, n.B
+			img.store16(alpha, x, y, n.A)
+		} else {
+			n := color.RGBA64Model.Convert(c).(color.RGBA64)
+			r, g, b = n.R, n.G, n.B
+		}
+		img.store16(0, x, y, r)
+		img.store16(1, x, y, g)
+		img.store16(2, x, y, b)
+	default:
+		img.storeRaw(0, x, y, uint32(img.palette().Index(c)))
+	}
+}
+
+// componentPoint maps pixel coordinates to the sample coordinates of
+// component c.
+func (img *Image) componentPoint(c, x, y int) (int, int) {
+	if c == 1 || c == 2 {
+		desc := img.frame.desc
+		return x >> desc.Log2ChromaW(), y >> desc.Log2ChromaH()
+	}
+	return x, y
+}
+
+// samplePlanes returns the planes and column to address sample x of
+// component c with. Like libavutil, readLine and writeLine ignore x for the
+// 10-bit formats packing a pixel into a 32-bit word, so for those the plane
+// is advanced to the pixel instead.
+func (img *Image) samplePlanes(c, x int) ([4][]byte, int) {
+	f := img.frame
+	comp, _ := f.desc.Component(c)
+	if !f.desc.IsBitstream() || comp.Depth != 10 {
+		return f.data, x
+	}
+	data := f.data
+	data[comp.Plane] = data[comp.Plane][4*x:]
+	return data, 0
+}
+
+// raw returns the stored value of component c at pixel (x, y).
+func (img *Image) raw(c, x, y int) uint32 {
+	f := img.frame
+	x, y = img.componentPoint(c, x, y)
+	data, x := img.samplePlanes(c, x)
+	var v [1]uint32
+	readLine(v[:], data, f.linesize, f.desc, x, y, c, false)
+	return v[0]
+}
+
+// storeRaw replaces the stored value of component c at pixel (x, y).
+func (img *Image) storeRaw(c, x, y int, v uint32) {
+	f := img.frame
+	x, y = img.componentPoint(c, x, y)
+	data, x := img.samplePlanes(c, x)
+	writeLine([]uint32{v}, data, f.linesize, f.desc, x, y, c, true)
+}
+
+// sample16 returns component c at pixel (x, y) scaled to 16 bits.
+func (img *Image) sample16(c, x, y int) uint16 {
+	comp, _ := img.frame.desc.Component(c)
+	return decodeSample(comp.Depth, img.frame.desc.IsFloat(),
+		img.raw(c, x, y))
+}
+
+// sample8 returns component c at pixel (x, y) scaled to 8 bits.
+func (img *Image) sample8(c, x, y int) uint8 {
+	return uint8((uint32(img.sample16(c, x, y))*255 + 32767) / 65535)
+}
+
+// store16 stores a 16 bit value in component c at pixel (x, y).
+func (img *Image) store16(c, x, y int, v uint16) {
+	comp, _ := img.frame.desc.Component(c)
+	img.storeRaw(c, x, y,
+		encodeSample(comp.Depth, img.frame.desc.IsFloat(), v))
+}
+
+// store8 stores an 8 bit value in component c at pixel (x, y).
+func (img *Image) store8(c, x, y int, v uint8) {
+	img.store16(c, x, y, uint16(v)*0x101)
+}
+
+// paletteEntry returns entry i of the palette of a paletted frame. Entries
+// are stored as native-endian 0xAARRGGBB words.
+func (img *Image) paletteEntry(i uint32) color.RGBA64 {
+	v := binary.NativeEndian.Uint32(img.frame.data[1][4*i:])
+	return premultiply(uint16(v>>16&0xff)*0x101, uint16(v>>8&0xff)*0x101,
+		uint16(v&0xff)*0x101, uint16(v>>24)*0x101)
+}
+
+// palette returns the palette of a paletted frame. It is rebuilt only when
+// the palette bytes differ from the ones it was last built from, which is
+// far cheaper than resolving colors against it.
+func (img *Image) palette() color.Palette {
+	comp, _ := img.frame.desc.Component(0)
+	live := img.frame.data[1][:4<<comp.Depth]
+	if img.pal != nil && bytes.Equal(img.palRaw, live) {
+		return img.pal
+	}
+	if img.pal == nil {
+		img.pal = make(color.Palette, 1<<comp.Depth)
+	}
+	for i := range img.pal {
+		img.pal[i] = img.paletteEntry(uint32(i))
+	}
+	img.palRaw = append(img.palRaw[:0], live...)
+	return img.pal
+}
+
+// premultiply converts straight alpha components to a color.RGBA64.
+func premultiply(r, g, b, a uint16) color.RGBA64 {
+	return color.RGBA64Model.Convert(color.NRGBA64{R: r, G: g, B: b,
+		A: a}).(color.RGBA64)
+}
+
+// decodeSample scales a stored component value of the given depth to 16 bits.
+// Floating point samples are clamped to [0, 1].
+func decodeSample(depth int, float bool, v uint32) uint16 {
+	if float {
+		var f float64
+		if depth == 16 {
+			f = halfToFloat(uint16(v))
+		} else {
+			f = float64(math.Float32frombits(v))
+		}
+		if !(f > 0) {
+			return 0
+		}
+		return uint16(math.Min(f, 1)*0xffff + 0.5)
+	}
+	if depth >= 16 {
+		return uint16(v >> (depth - 16))
+	}
+	maxv := uint32(1)<<depth - 1
+	return uint16((v*0xffff + maxv/2) / maxv)
+}
+
+// encodeSample is the inverse of decodeSample.
+func encodeSample(depth int, float bool, v uint16) uint32 {
+	if float {
+		f := float64(v) / 0xffff
+		if depth == 16 {
+			return uint32(floatToHalf(f))
+		}
+		return math.Float32bits(float32(f))
+	}
+	if depth >= 16 {
+		return uint32(v) << (depth - 16)
+	}
+	maxv := uint32(1)<<depth - 1
+	return (uint32(v)*maxv + 0x7fff) / 0xffff
+}
+
+// halfToFloat decodes an IEEE-754 half precision value.
+func halfToFloat(h uint16) float64 {
+	exp, mant := int(h>>10&0x1f), float64(h&0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 0x1f:
+		f = math.Inf(1)
+		if mant != 0 {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+0x400, exp-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+// floatToHalf encodes f as an IEEE-754 half precision value, rounding to
+// nearest.
+func floatToHalf(f float64) uint16 {
+	bits := math.Float32bits(float32(f))
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+	switch {
+	case math.IsNaN(f):
+		return sign | 0x7e00
+	case exp >= 0x1f:
+		return sign | 0x7c00
+	case exp <= 0:
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - exp)
+		h := uint16(mant >> shift)
+		if mant>>(shift-1)&1 != 0 {
+			h++
+		}
+		return sign | h
+	}
+	h := sign | uint16(exp)<<10 | uint16(mant>>13)
+	if mant&0x1000 != 0 {
+		h++
+	}
+	return h
+}
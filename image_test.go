@@ -0,0 +1,144 @@
+package gopixfmts_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+var _ draw.Image = (*pixfmts.Image)(nil)
+
+func newImage(t *testing.T, pf pixfmts.PixelFormat, w,
+	h int) *pixfmts.Image {
+	t.Helper()
+	frame, err := pixfmts.NewFrame(pf, w, h, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := pixfmts.NewImage(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func Test_Image_RGB24(t *testing.T) {
+	img := newImage(t, pixfmts.PixFmtRGB24, 2, 2)
+
+	img.Set(1, 0, color.RGBA{R: 0xff, G: 0x80, A: 0xff})
+	want := color.RGBA64{R: 0xffff, G: 0x8080, A: 0xffff}
+	if got := img.At(1, 0); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if p := img.Frame().Planes()[0]; p[3] != 0xff || p[4] != 0x80 ||
+		p[5] != 0 {
+		t.Fatalf("unexpected packed pixel: % x", p[3:6])
+	}
+}
+
+func Test_Image_YUV420P(t *testing.T) {
+	img := newImage(t, pixfmts.PixFmtYUV420P, 4, 4)
+	if img.ColorModel() != color.YCbCrModel {
+		t.Fatal("unexpected color model")
+	}
+
+	want := color.YCbCr{Y: 100, Cb: 50, Cr: 200}
+	draw.Draw(img, img.Bounds(), image.NewUniform(want), image.Point{},
+		draw.Src)
+	if got := img.At(3, 3); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func Test_Image_Gray10(t *testing.T) {
+	img := newImage(t, pixfmts.PixFmtGRAY10LE, 1, 1)
+
+	img.Set(0, 0, color.Gray16{Y: 0xffff})
+	if p := img.Frame().Planes()[0]; p[0] != 0xff || p[1] != 0x03 {
+		t.Fatalf("unexpected sample: % x", p[:2])
+	}
+	img.Set(0, 0, color.Black)
+	if got := img.At(0, 0); got != (color.Gray16{}) {
+		t.Fatalf("sample was not overwritten: %v", got)
+	}
+}
+
+func Test_Image_Pal8(t *testing.T) {
+	img := newImage(t, pixfmts.PixFmtPal8, 1, 1)
+
+	// Palette entry 1 is opaque red, stored as a native 0xAARRGGBB word.
+	binary.NativeEndian.PutUint32(img.Frame().Planes()[1][4:], 0xffff0000)
+
+	img.Set(0, 0, color.RGBA{R: 0xf0, A: 0xff})
+	if idx := img.Frame().Planes()[0][0]; idx != 1 {
+		t.Fatalf("unexpected palette index: %d", idx)
+	}
+	want := color.RGBA64{R: 0xffff, A: 0xffff}
+	if got := img.At(0, 0); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// Later writes to the palette plane are picked up by Set.
+	binary.NativeEndian.PutUint32(img.Frame().Planes()[1][8:], 0xffff0101)
+	img.Set(0, 0, color.RGBA{R: 0xff, G: 0x01, B: 0x01, A: 0xff})
+	if idx := img.Frame().Planes()[0][0]; idx != 2 {
+		t.Fatalf("unexpected palette index after update: %d", idx)
+	}
+
+	// So is the palette Convert writes.
+	rgb, err := pixfmts.NewFrame(pixfmts.PixFmtRGB24, 1, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pixfmts.Convert(img.Frame(), rgb); err != nil {
+		t.Fatal(err)
+	}
+	img.Set(0, 0, color.RGBA{B: 0xff, A: 0xff})
+	if got := img.At(0, 0).(color.RGBA64); got.B < 0xf000 || got.R != 0 {
+		t.Fatalf("palette not refreshed after Convert: %v", got)
+	}
+}
+
+func Test_NewImage_Unsupported(t *testing.T) {
+	frame, err := pixfmts.NewFrame(pixfmts.PixFmtBAYER_RGGB8, 2, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pixfmts.NewImage(frame); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
+
+func Test_Image_RGBAF16(t *testing.T) {
+	img := newImage(t, pixfmts.PixFmtRGBAF16LE, 1, 1)
+
+	img.Set(0, 0, color.NRGBA64{R: 0xffff, G: 0x8000, B: 0, A: 0xffff})
+	if p := img.Frame().Planes()[0]; p[0] != 0x00 || p[1] != 0x3c {
+		t.Fatalf("unexpected half float for 1.0: % x", p[:2])
+	}
+	got := img.At(0, 0).(color.RGBA64)
+	if got.R != 0xffff || got.G < 0x7fe0 || got.G > 0x8020 || got.B != 0 {
+		t.Fatalf("unexpected color: %v", got)
+	}
+}
+
+func Test_Image_XV30BE(t *testing.T) {
+	// The image addresses every pixel of the 10-bit word formats, even
+	// though the image line functions ignore x for them.
+	img := newImage(t, pixfmts.PixFmtXV30BE, 2, 1)
+	img.Set(0, 0, color.YCbCr{Y: 0x10, Cb: 0x80, Cr: 0x80})
+	img.Set(1, 0, color.YCbCr{Y: 0xeb, Cb: 0x40, Cr: 0xc0})
+	want := []color.YCbCr{{Y: 0x10, Cb: 0x80, Cr: 0x80},
+		{Y: 0xeb, Cb: 0x40, Cr: 0xc0}}
+	for x, w := range want {
+		if got := img.At(x, 0); got != w {
+			t.Fatalf("pixel %d: got %v, want %v", x, got, w)
+		}
+	}
+}
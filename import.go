@@ -0,0 +1,247 @@
+package gopixfmts
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"math"
+)
+
+// ImportOptions controls how NewFrameFromImage converts colors.
+type ImportOptions struct {
+	// ColorSpace selects the RGB to YUV matrix. ColorSpaceUnspecified and
+	// the zero value, ColorSpaceRGB, use BT.601, which matches image/color.
+	// RGB data is stored in the gbrp formats rather than behind an identity
+	// matrix. Other color spaces must be supported by RGBToYUVMatrix.
+	ColorSpace ColorSpace
+
+	// ColorRange selects the quantization of YUV and gray samples.
+	// ColorRangeUnspecified uses limited (MPEG) range for YUV formats, except
+	// for the yuvj formats, and full (JPEG) range for gray formats.
+	ColorRange ColorRange
+
+	// ChromaLocation places the chroma samples of subsampled formats.
+	// ChromaLocationUnspecified uses ChromaLocationLeft.
+	ChromaLocation ChromaLocation
+
+	// Align is the linesize alignment passed to NewFrame.
+	Align int
+}
+
+// NewFrameFromImage converts src into a new frame of the given pixel format.
+//
+// RGB and paletted targets receive the image's straight alpha R'G'B' values.
+// YUV and gray targets go through the matrix, range and chroma siting
+// selected by opts, which may be nil for the defaults. Chroma is
+// downsampled with a triangle filter centred on each chroma sample.
+// *image.YCbCr and *image.NYCbCrA sources are read without rounding to 8-bit
+// RGB first. Paletted targets get the Plan 9 palette.
+//
+// The frame covers src.Bounds() with its origin moved to (0, 0). Hardware
+// accelerated, Bayer and XYZ formats, and color spaces RGBToYUVMatrix
+// rejects, return ErrInvalidArgument.
+func NewFrameFromImage(src image.Image, pf PixelFormat,
+	opts *ImportOptions) (*Frame, error) {
+	if src == nil {
+		return nil, ErrInvalidArgument
+	}
+	var o ImportOptions
+	if opts != nil {
+		o = *opts
+	}
+	fwd, _, err := matricesOf(o.ColorSpace)
+	if err != nil {
+		return nil, fmt.Errorf("%w: color space %v has no RGB to YUV matrix",
+			ErrInvalidArgument, o.ColorSpace)
+	}
+	desc, err := PixFmtDescGet(pf)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := imageKindOf(desc)
+	if err != nil {
+		return nil, err
+	}
+	b := src.Bounds()
+	f, err := NewFrame(pf, b.Dx(), b.Dy(), o.Align)
+	if err != nil {
+		return nil, err
+	}
+
+	// Gather straight alpha R'G'B'A planes normalized to [0, 1].
+	w, h := f.width, f.height
+	var rgba [4][]float32
+	for i := range rgba {
+		rgba[i] = make([]float32, w*h)
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, bl, a := normalizedRGBA(src, b.Min.X+x, b.Min.Y+y)
+			i := y*w + x
+			rgba[0][i], rgba[1][i], rgba[2][i], rgba[3][i] = float32(r),
+				float32(g), float32(bl), float32(a)
+		}
+	}
+
+	switch kind {
+	case imageRGB:
+		for c := 0; c < desc.NbComponents(); c++ {
+			f.storePlane(c, rgba[min(c, 3)], identity)
+		}
+	case imagePaletted:
+		f.storePaletted(rgba)
+	case imageGray:
+		full := o.ColorRange != ColorRangeMPEG
+		luma := make([]float32, w*h)
+		for i := range luma {
+			y, _, _ := fwd.Apply(float64(rgba[0][i]),
+				float64(rgba[1][i]), float64(rgba[2][i]))
+			luma[i] = float32(y)
+		}
+		f.storeLevels(0, luma, full, false)
+		if desc.NbComponents() > 1 {
+			f.storePlane(1, rgba[3], identity)
+		}
+	case imageYCbCr:
+		full := o.ColorRange == ColorRangeJPEG ||
+			o.ColorRange == ColorRangeUnspecified && defaultFullRange(desc)
+		var yuv [3][]float32
+		for i := range yuv {
+			yuv[i] = make([]float32, w*h)
+		}
+		for i := range yuv[0] {
+			y, u, v := fwd.Apply(float64(rgba[0][i]),
+				float64(rgba[1][i]), float64(rgba[2][i]))
+			yuv[0][i], yuv[1][i], yuv[2][i] = float32(y), float32(u),
+				float32(v)
+		}
+		loc := o.ChromaLocation
+		if loc == ChromaLocationUnspecified {
+			loc = ChromaLocationLeft
+		}
+		xpos, ypos, _ := goChromaLocationEnumToPos(int(loc))
+		f.storeLevels(0, yuv[0], full, false)
+		for c := 1; c <= 2; c++ {
+			chroma := resampleChroma(yuv[c], w, h, 0, 0, desc.Log2ChromaW(),
+				desc.Log2ChromaH(), xpos, ypos)
+			f.storeLevels(c, chroma, full, true)
+		}
+		if desc.NbComponents() > 3 {
+			f.storePlane(3, rgba[3], identity)
+		}
+	}
+	return f, nil
+}
+
+// normalizedRGBA returns the straight alpha color of src at (x, y) with
+// every component in [0, 1]. Y'CbCr images are converted with BT.601 full
+// range math without intermediate rounding.
+func normalizedRGBA(src image.Image, x, y int) (r, g, b, a float64) {
+	var ycc color.YCbCr
+	a = 1
+	switch img := src.(type) {
+	case *image.YCbCr:
+		ycc = img.YCbCrAt(x, y)
+	case *image.NYCbCrA:
+		c := img.NYCbCrAAt(x, y)
+		ycc, a = c.YCbCr, float64(c.A)/0xff
+	default:
+		c := color.NRGBA64Model.Convert(src.At(x, y)).(color.NRGBA64)
+		return float64(c.R) / 0xffff, float64(c.G) / 0xffff,
+			float64(c.B) / 0xffff, float64(c.A) / 0xffff
+	}
+	_, inv, _ := matricesOf(ColorSpaceSMPTE170M)
+	r, g, b = inv.Apply(float64(ycc.Y)/0xff,
+		(float64(ycc.Cb)-128)/0xff, (float64(ycc.Cr)-128)/0xff)
+	return r, g, b, a
+}
+
+// storePlane writes every sample of component c from a plane of frame
+// sized values, mapped to normalized [0, 1] values by norm. Chroma
+// components must already be subsampled.
+func (f *Frame) storePlane(c int, plane []float32,
+	norm func(float32) float64) {
+	comp, _ := f.desc.Component(c)
+	float := f.desc.IsFloat()
+	w, h := componentSize(f.desc, c, f.width, f.height)
+	row := make([]uint32, w)
+	for y := 0; y < h; y++ {
+		for x := range row {
+			row[x] = encodeNormalized(comp.Depth, float, norm(plane[y*w+x]))
+		}
+		writeLine(row, f.data, f.linesize, f.desc, 0, y, c, true)
+	}
+}
+
+// storeLevels writes luma, or chroma when chroma is set, quantized to the
+// limited or full range levels of the component's depth. Luma is expected
+// in [0, 1] and chroma in [-0.5, 0.5].
+func (f *Frame) storeLevels(c int, plane []float32, full, chroma bool) {
+	comp, _ := f.desc.Component(c)
+	if f.desc.IsFloat() {
+		f.storePlane(c, plane, func(v float32) float64 {
+			if chroma {
+				return float64(v) + 0.5
+			}
+			return float64(v)
+		})
+		return
+	}
+	if f.format == PixFmtMonoWhite {
+		f.storePlane(c, plane, func(v float32) float64 { return 1 - float64(v) })
+		return
+	}
+	levels := levelsOf(comp.Depth, full)
+	maxv := float64(uint64(1)<<comp.Depth - 1)
+	f.storePlane(c, plane, func(v float32) float64 {
+		return float64(levels.Quantize(float64(v), chroma)) / maxv
+	})
+}
+
+// storePaletted quantizes straight alpha RGBA planes to the Plan 9 palette
+// and stores the palette in plane 1.
+func (f *Frame) storePaletted(rgba [4][]float32) {
+	pal := color.Palette(palette.Plan9)
+	for i, c := range pal {
+		r, g, b, a := c.RGBA()
+		binary.NativeEndian.PutUint32(f.data[1][4*i:],
+			a>>8<<24|r>>8<<16|g>>8<<8|b>>8)
+	}
+	w := f.width
+	row := make([]uint32, w)
+	for y := 0; y < f.height; y++ {
+		for x := range row {
+			i := y*w + x
+			row[x] = uint32(pal.Index(color.NRGBA64{
+				R: unorm16(rgba[0][i]),
+				G: unorm16(rgba[1][i]),
+				B: unorm16(rgba[2][i]),
+				A: unorm16(rgba[3][i]),
+			}))
+		}
+		writeLine(row, f.data, f.linesize, f.desc, 0, y, 0, true)
+	}
+}
+
+// unorm16 converts a normalized value to 16 bits, clamping it to [0, 1].
+func unorm16(v float32) uint16 {
+	return uint16(encodeNormalized(16, false, float64(v)))
+}
+
+// identity passes normalized samples through unchanged.
+func identity(v float32) float64 { return float64(v) }
+
+// encodeNormalized converts a value in [0, 1] to the stored representation
+// of a component of the given depth, clamping out of range values.
+func encodeNormalized(depth int, float bool, v float64) uint32 {
+	if float {
+		if depth == 16 {
+			return uint32(floatToHalf(v))
+		}
+		return math.Float32bits(float32(v))
+	}
+	maxv := float64(uint64(1)<<depth - 1)
+	return uint32(math.Round(math.Max(0, math.Min(v, 1)) * maxv))
+}
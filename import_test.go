@@ -0,0 +1,105 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_NewFrameFromImage_LimitedRange(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := range src.Pix {
+		src.Pix[i] = 0xff
+	}
+
+	frame, err := pixfmts.NewFrameFromImage(src, pixfmts.PixFmtP010LE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	luma, err := pixfmts.ReadComponent(frame, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := pixfmts.ReadComponent(frame, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if luma.Samples[0] != 940 || cb.Samples[0] != 512 {
+		t.Fatalf("unexpected white: Y=%d Cb=%d", luma.Samples[0],
+			cb.Samples[0])
+	}
+}
+
+func Test_NewFrameFromImage_YCbCr(t *testing.T) {
+	src := image.NewYCbCr(image.Rect(0, 0, 6, 4), image.YCbCrSubsampleRatio420)
+	for i := range src.Y {
+		src.Y[i] = 100
+	}
+	for i := range src.Cb {
+		src.Cb[i], src.Cr[i] = 50, 200
+	}
+
+	opts := &pixfmts.ImportOptions{ColorSpace: pixfmts.ColorSpaceSMPTE170M,
+		ColorRange: pixfmts.ColorRangeJPEG}
+	frame, err := pixfmts.NewFrameFromImage(src, pixfmts.PixFmtYUV420P, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	planes := frame.Planes()
+	if planes[0][0] != 100 || planes[1][0] != 50 || planes[2][0] != 200 {
+		t.Fatalf("samples changed: %d %d %d", planes[0][0], planes[1][0],
+			planes[2][0])
+	}
+}
+
+func Test_NewFrameFromImage_ChromaLocation(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		src.Set(0, y, color.RGBA{R: 0xff, A: 0xff})
+	}
+
+	cr := func(loc pixfmts.ChromaLocation) byte {
+		frame, err := pixfmts.NewFrameFromImage(src, pixfmts.PixFmtYUV420P,
+			&pixfmts.ImportOptions{ChromaLocation: loc})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return frame.Planes()[2][0]
+	}
+	// A left sited chroma sample sits on the red column, a centred one
+	// between the red and the black column.
+	if left, center := cr(pixfmts.ChromaLocationLeft),
+		cr(pixfmts.ChromaLocationCenter); left <= center || center <= 128 {
+		t.Fatalf("unexpected siting: left %d, center %d", left, center)
+	}
+}
+
+func Test_NewFrameFromImage_Gray16(t *testing.T) {
+	src := image.NewGray16(image.Rect(0, 0, 2, 1))
+	src.SetGray16(1, 0, color.Gray16{Y: 0x1234})
+
+	frame, err := pixfmts.NewFrameFromImage(src, pixfmts.PixFmtGray16LE, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := frame.Planes()[0]; p[2] != 0x34 || p[3] != 0x12 {
+		t.Fatalf("unexpected sample: % x", p[:4])
+	}
+}
+
+func Test_NewFrameFromImage_UnsupportedColorSpace(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for _, cs := range []pixfmts.ColorSpace{pixfmts.ColorSpaceICTCP,
+		pixfmts.ColorSpaceIPT_C2, pixfmts.ColorSpaceSMPTE2085,
+		pixfmts.ColorSpaceCHROMA_DERIVED_NCL,
+		pixfmts.ColorSpaceCHROMA_DERIVED_CL} {
+		_, err := pixfmts.NewFrameFromImage(src, pixfmts.PixFmtYUV420P,
+			&pixfmts.ImportOptions{ColorSpace: cs})
+		if !errors.Is(err, pixfmts.ErrInvalidArgument) {
+			t.Fatalf("%v: got %v, want ErrInvalidArgument", cs, err)
+		}
+	}
+}
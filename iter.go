@@ -0,0 +1,108 @@
+package gopixfmts
+
+import "iter"
+
+// AllPixFmtDescs returns an iterator over every pixel format descriptor known
+// to libavutil, in the order reported by PixFmtDescNext.
+func AllPixFmtDescs() iter.Seq[*PixFmtDescRef] {
+	return func(yield func(*PixFmtDescRef) bool) {
+		var desc *PixFmtDescRef
+		for desc = desc.PixFmtDescNext(); desc != nil; desc = desc.PixFmtDescNext() {
+			if !yield(desc) {
+				return
+			}
+		}
+	}
+}
+
+// AllPixelFormats returns an iterator over every known pixel format together
+// with its descriptor. Descriptors that do not map back to a pixel format are
+// skipped.
+func AllPixelFormats() iter.Seq2[PixelFormat, *PixFmtDescRef] {
+	return func(yield func(PixelFormat, *PixFmtDescRef) bool) {
+		for desc := range AllPixFmtDescs() {
+			pf, err := desc.PixFmtDescID()
+			if err != nil {
+				continue
+			}
+			if !yield(pf, desc) {
+				return
+			}
+		}
+	}
+}
+
+// AllColorPrimaries returns an iterator over every valid ColorPrimaries value.
+// Reserved values and the unnamed gaps between them are skipped.
+func AllColorPrimaries() iter.Seq[ColorPrimaries] {
+	return func(yield func(ColorPrimaries) bool) {
+		for p := ColorPrimaries(0); p < ColorPrimariesNB; p++ {
+			if p == ColorPrimariesReserved0 || p == ColorPrimariesReserved ||
+				ColorPrimariesName(int(p)) == "" {
+				continue
+			}
+			if !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// AllColorTransferCharacteristics returns an iterator over every valid
+// ColorTransferCharacteristic value. Reserved values are skipped.
+func AllColorTransferCharacteristics() iter.Seq[ColorTransferCharacteristic] {
+	return func(yield func(ColorTransferCharacteristic) bool) {
+		for t := ColorTransferCharacteristic(0); t < ColorTransferCharacteristicNB; t++ {
+			if t == ColorTransferCharacteristicReserved0 ||
+				t == ColorTransferCharacteristicReserved ||
+				ColorTransferName(int(t)) == "" {
+				continue
+			}
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// AllColorSpaces returns an iterator over every valid ColorSpace value.
+// Reserved values are skipped.
+func AllColorSpaces() iter.Seq[ColorSpace] {
+	return func(yield func(ColorSpace) bool) {
+		for s := ColorSpace(0); s < ColorSpaceNB; s++ {
+			if s == ColorSpaceReserved || ColorSpaceName(int(s)) == "" {
+				continue
+			}
+			if !yield(s) {
+				return
+			}
+		}
+	}
+}
+
+// AllColorRanges returns an iterator over every ColorRange value, starting
+// with ColorRangeUnspecified.
+func AllColorRanges() iter.Seq[ColorRange] {
+	return func(yield func(ColorRange) bool) {
+		for r := ColorRange(0); ColorRangeName(int(r)) != ""; r++ {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// AllChromaLocations returns an iterator over every ChromaLocation value,
+// starting with ChromaLocationUnspecified.
+func AllChromaLocations() iter.Seq[ChromaLocation] {
+	return func(yield func(ChromaLocation) bool) {
+		for l := ChromaLocation(0); l < ChromaLocationNB; l++ {
+			if ChromaLocationName(int(l)) == "" {
+				continue
+			}
+			if !yield(l) {
+				return
+			}
+		}
+	}
+}
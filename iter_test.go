@@ -0,0 +1,33 @@
+package gopixfmts_test
+
+import (
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_AllPixelFormats(t *testing.T) {
+	count := 0
+	for pf, desc := range pixfmts.AllPixelFormats() {
+		if name := pixfmts.GetPixFmtName(pf); name != desc.Name() {
+			t.Fatalf("%d: descriptor name %q, format name %q", pf,
+				desc.Name(), name)
+		}
+		count++
+	}
+	if count == 0 {
+		t.FailNow()
+	}
+
+	t.Log(count)
+}
+
+func Test_AllColorSpaces(t *testing.T) {
+	seen := map[pixfmts.ColorSpace]bool{}
+	for s := range pixfmts.AllColorSpaces() {
+		seen[s] = true
+	}
+	if !seen[pixfmts.ColorSpaceBT709] || seen[pixfmts.ColorSpaceReserved] {
+		t.Fatalf("unexpected color spaces: %v", seen)
+	}
+}
@@ -0,0 +1,203 @@
+package gopixfmts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Loss is a bitset of FF_LOSS_* values describing what a pixel format
+// conversion loses.
+type Loss int
+
+// lossNames lists every known loss bit in bit order with the name used by
+// Loss.String.
+var lossNames = []struct {
+	loss Loss
+	name string
+}{
+	{FF_LOSS_RESOLUTION, "resolution"},
+	{FF_LOSS_DEPTH, "depth"},
+	{FF_LOSS_COLORSPACE, "colorspace"},
+	{FF_LOSS_ALPHA, "alpha"},
+	{FF_LOSS_COLORQUANT, "colorquant"},
+	{FF_LOSS_CHROMA, "chroma"},
+	{FF_LOSS_EXCESS_RESOLUTION, "excess_resolution"},
+	{FF_LOSS_EXCESS_DEPTH, "excess_depth"},
+}
+
+// Has reports whether every bit of loss is set in l.
+func (l Loss) Has(loss Loss) bool {
+	return l&loss == loss
+}
+
+// String returns the set bits joined by '|', for example
+// "depth|chroma|excess_resolution". Bits without a known name are appended
+// in hexadecimal. No loss is reported as "none".
+func (l Loss) String() string {
+	if l == 0 {
+		return "none"
+	}
+	var names []string
+	for _, n := range lossNames {
+		if l.Has(n.loss) {
+			names = append(names, n.name)
+			l &^= n.loss
+		}
+	}
+	if l != 0 {
+		names = append(names, fmt.Sprintf("%#x", int(l)))
+	}
+	return strings.Join(names, "|")
+}
+
+// lossError converts the negative result of av_get_pix_fmt_loss into an
+// error.
+func lossError(ret int) error {
+	switch ret {
+	case -4:
+		return ErrUnknownPixelFormat
+	case -3:
+		return fmt.Errorf("%w: format without components",
+			ErrInvalidArgument)
+	}
+	return fmt.Errorf("%w: hardware accelerated format", ErrInvalidArgument)
+}
+
+// ---------------- Explanations ----------------
+
+// DepthChange records a component whose bit depth differs between the
+// source and the destination format.
+type DepthChange struct {
+	Component int
+	From, To  int
+}
+
+// LossExplanation breaks the Loss of a conversion down into its causes.
+type LossExplanation struct {
+	// Loss is what GetPixFmtLoss reports with alpha considered.
+	Loss Loss
+
+	// Depths lists the compared components whose depth shrinks, causing
+	// FF_LOSS_DEPTH, or grows, causing FF_LOSS_EXCESS_DEPTH. Paletted
+	// destinations count as 8 bits spread over the source components.
+	Depths []DepthChange
+
+	// Log2 chroma subsampling factors of the source and the destination.
+	SrcLog2ChromaW, SrcLog2ChromaH int
+	DstLog2ChromaW, DstLog2ChromaH int
+
+	// SrcColor and DstColor name the color families libavutil compares:
+	// "rgb", "gray", "yuv", "yuvj" or "xyz".
+	SrcColor, DstColor string
+
+	// AlphaDropped is set when the source has alpha and the destination
+	// does not.
+	AlphaDropped bool
+}
+
+// colorTypeNames names the results of colorType.
+var colorTypeNames = map[int]string{
+	colorNA:      "none",
+	colorRGB:     "rgb",
+	colorGray:    "gray",
+	colorYUV:     "yuv",
+	colorYUVJPEG: "yuvj",
+	colorXYZ:     "xyz",
+}
+
+// Explain reports why converting src to dst loses what it does, considering
+// alpha. Unknown formats return ErrUnknownPixelFormat and pairs libavutil
+// cannot compare, such as hardware formats, return ErrInvalidArgument.
+//
+// Like FindBestPixFmtOfList it works from the generated descriptor table in
+// every build, so formats newer than the table are unknown to it.
+func Explain(dst, src PixelFormat) (*LossExplanation, error) {
+	score, loss := goPixFmtScore(dst, src, lossMask(true))
+	if score < 0 {
+		return nil, lossError(score)
+	}
+	sd, dd := goPixFmtDescGet(src), goPixFmtDescGet(dst)
+	e := &LossExplanation{
+		Loss:           Loss(loss),
+		SrcLog2ChromaW: sd.log2ChromaW,
+		SrcLog2ChromaH: sd.log2ChromaH,
+		DstLog2ChromaW: dd.log2ChromaW,
+		DstLog2ChromaH: dd.log2ChromaH,
+		SrcColor:       colorTypeNames[colorType(sd)],
+		DstColor:       colorTypeNames[colorType(dd)],
+		AlphaDropped:   loss&FF_LOSS_ALPHA != 0,
+	}
+	if dst == src {
+		return e, nil
+	}
+
+	// Compare depths the way goPixFmtScore does.
+	n := min(sd.nbComponents, dd.nbComponents)
+	if dst == PixFmtPal8 {
+		n = min(sd.nbComponents, 4)
+	}
+	for i := 0; i < n; i++ {
+		to := dd.comp[i].Depth
+		if dst == PixFmtPal8 {
+			to = 7/n + 1
+		}
+		if from := sd.comp[i].Depth; from != to {
+			e.Depths = append(e.Depths, DepthChange{i, from, to})
+		}
+	}
+	return e, nil
+}
+
+// Reasons returns one human readable sentence per cause of the loss.
+func (e *LossExplanation) Reasons() []string {
+	var out []string
+	for _, d := range e.Depths {
+		switch {
+		case d.To < d.From && e.Loss.Has(FF_LOSS_DEPTH):
+			out = append(out, fmt.Sprintf(
+				"component %d loses depth from %d to %d bits", d.Component,
+				d.From, d.To))
+		case d.To > d.From && e.Loss.Has(FF_LOSS_EXCESS_DEPTH):
+			out = append(out, fmt.Sprintf(
+				"component %d grows from %d to %d bits", d.Component, d.From,
+				d.To))
+		}
+	}
+	sub := func(w, h int) string {
+		return fmt.Sprintf("%dx%d", 1<<w, 1<<h)
+	}
+	src := sub(e.SrcLog2ChromaW, e.SrcLog2ChromaH)
+	dst := sub(e.DstLog2ChromaW, e.DstLog2ChromaH)
+	if e.Loss.Has(FF_LOSS_RESOLUTION) {
+		out = append(out, fmt.Sprintf(
+			"chroma is subsampled further, from %s to %s", src, dst))
+	}
+	if e.Loss.Has(FF_LOSS_EXCESS_RESOLUTION) {
+		out = append(out, fmt.Sprintf("chroma is upsampled from %s to %s",
+			src, dst))
+	}
+	if e.Loss.Has(FF_LOSS_COLORSPACE) {
+		out = append(out, fmt.Sprintf("color space changes from %s to %s",
+			e.SrcColor, e.DstColor))
+	}
+	if e.Loss.Has(FF_LOSS_CHROMA) {
+		out = append(out, "chroma is dropped")
+	}
+	if e.AlphaDropped {
+		out = append(out, "alpha is dropped")
+	}
+	if e.Loss.Has(FF_LOSS_COLORQUANT) {
+		out = append(out, "colors are quantized to a palette")
+	}
+	return out
+}
+
+// String returns the loss followed by its reasons, such as
+// "depth: component 0 loses depth from 10 to 8 bits; ...".
+func (e *LossExplanation) String() string {
+	reasons := e.Reasons()
+	if len(reasons) == 0 {
+		return e.Loss.String()
+	}
+	return e.Loss.String() + ": " + strings.Join(reasons, "; ")
+}
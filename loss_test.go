@@ -0,0 +1,60 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_Loss_String(t *testing.T) {
+	l := pixfmts.Loss(pixfmts.FF_LOSS_DEPTH | pixfmts.FF_LOSS_CHROMA |
+		pixfmts.FF_LOSS_EXCESS_RESOLUTION)
+	if got := l.String(); got != "depth|chroma|excess_resolution" {
+		t.Fatalf("unexpected string %q", got)
+	}
+	if !l.Has(pixfmts.FF_LOSS_DEPTH|pixfmts.FF_LOSS_CHROMA) ||
+		l.Has(pixfmts.FF_LOSS_ALPHA) {
+		t.Fatal("unexpected Has result")
+	}
+	if got := pixfmts.Loss(0x100).String(); got != "0x100" {
+		t.Fatalf("unexpected string %q", got)
+	}
+}
+
+func Test_GetPixFmtLoss_Errors(t *testing.T) {
+	if _, err := pixfmts.GetPixFmtLoss(pixfmts.PixFmtVAAPI,
+		pixfmts.PixFmtYUV420P, false); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	if _, err := pixfmts.GetPixFmtLoss(pixfmts.PixFmtNB,
+		pixfmts.PixFmtYUV420P, false); !errors.Is(err,
+		pixfmts.ErrUnknownPixelFormat) {
+		t.Fatalf("expected unknown pixel format, got %v", err)
+	}
+}
+
+func Test_Explain(t *testing.T) {
+	e, err := pixfmts.Explain(pixfmts.PixFmtGray8, pixfmts.PixFmtYUVA420P10LE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := pixfmts.GetPixFmtLoss(pixfmts.PixFmtGray8,
+		pixfmts.PixFmtYUVA420P10LE, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Loss != want || !e.AlphaDropped {
+		t.Fatalf("unexpected explanation %+v", e)
+	}
+	if !slices.Contains(e.Depths, pixfmts.DepthChange{Component: 0,
+		From: 10, To: 8}) {
+		t.Fatalf("missing luma depth change: %v", e.Depths)
+	}
+	if !slices.Contains(e.Reasons(), "alpha is dropped") ||
+		!slices.Contains(e.Reasons(), "chroma is dropped") {
+		t.Fatalf("unexpected reasons: %s", e)
+	}
+}
@@ -0,0 +1,215 @@
+package gopixfmts
+
+import "math"
+
+// Matrix3x3 is a row-major 3x3 matrix applied to column vectors.
+type Matrix3x3 [3][3]float64
+
+// Apply returns m multiplied by the column vector (a, b, c).
+func (m Matrix3x3) Apply(a, b, c float64) (x, y, z float64) {
+	return m[0][0]*a + m[0][1]*b + m[0][2]*c,
+		m[1][0]*a + m[1][1]*b + m[1][2]*c,
+		m[2][0]*a + m[2][1]*b + m[2][2]*c
+}
+
+// Mul returns the product m * n, which applies n first.
+func (m Matrix3x3) Mul(n Matrix3x3) Matrix3x3 {
+	var out Matrix3x3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				out[i][j] += m[i][k] * n[k][j]
+			}
+		}
+	}
+	return out
+}
+
+// Inverse returns the inverse of m. A singular matrix returns
+// ErrInvalidArgument.
+func (m Matrix3x3) Inverse() (Matrix3x3, error) {
+	var inv Matrix3x3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			// Cofactor of m[j][i], which is the adjugate's (i, j) entry.
+			r0, r1 := (j+1)%3, (j+2)%3
+			c0, c1 := (i+1)%3, (i+2)%3
+			inv[i][j] = m[r0][c0]*m[r1][c1] - m[r0][c1]*m[r1][c0]
+		}
+	}
+	det := m[0][0]*inv[0][0] + m[0][1]*inv[1][0] + m[0][2]*inv[2][0]
+	if det == 0 || math.IsNaN(det) {
+		return Matrix3x3{}, ErrInvalidArgument
+	}
+	for i := range inv {
+		for j := range inv[i] {
+			inv[i][j] /= det
+		}
+	}
+	return inv, nil
+}
+
+// ---------------- Color space matrices ----------------
+
+// LumaCoefficients returns the Kr and Kb luma coefficients of a color space,
+// following av_csp_luma_coeffs_from_avcsp. Kg is 1 - Kr - Kb.
+//
+// Color spaces that are not derived from Kr and Kb, such as RGB, YCgCo and
+// ICtCp, and unspecified or reserved values return ErrInvalidArgument.
+func LumaCoefficients(cs ColorSpace) (kr, kb float64, err error) {
+	switch cs {
+	case ColorSpaceBT470BG, ColorSpaceSMPTE170M:
+		return 0.299, 0.114, nil
+	case ColorSpaceBT709:
+		return 0.2126, 0.0722, nil
+	case ColorSpaceFCC:
+		return 0.30, 0.11, nil
+	case ColorSpaceSMPTE240M:
+		return 0.212, 0.087, nil
+	case ColorSpaceBT2020_NCL, ColorSpaceBT2020_CL:
+		return 0.2627, 0.0593, nil
+	}
+	return 0, 0, ErrInvalidArgument
+}
+
+// RGBToYUVMatrix returns the matrix converting normalized R'G'B' in [0, 1]
+// to luma in [0, 1] and chroma in [-0.5, 0.5] for a color space.
+//
+// Every color space with luma coefficients is supported, as are YCgCo and
+// the YCgCo-R variants, whose chroma rows are Cg and Co. YCgCo-R stores
+// chroma with one more bit than luma; normalized to their own range the
+// samples follow the YCgCo matrix. BT.2020 CL yields the non-constant
+// luminance matrix, which is its luma but not its chroma encoding. Other
+// color spaces return ErrInvalidArgument.
+func RGBToYUVMatrix(cs ColorSpace) (Matrix3x3, error) {
+	switch cs {
+	case ColorSpaceYCGCO, ColorSpaceYCGCO_RE, ColorSpaceYCGCO_RO:
+		return Matrix3x3{
+			{0.25, 0.5, 0.25},
+			{-0.25, 0.5, -0.25},
+			{0.5, 0, -0.5},
+		}, nil
+	}
+	kr, kb, err := LumaCoefficients(cs)
+	if err != nil {
+		return Matrix3x3{}, err
+	}
+	kg := 1 - kr - kb
+	cb, cr := 2*(1-kb), 2*(1-kr)
+	return Matrix3x3{
+		{kr, kg, kb},
+		{-kr / cb, -kg / cb, (1 - kb) / cb},
+		{(1 - kr) / cr, -kg / cr, -kb / cr},
+	}, nil
+}
+
+// YUVToRGBMatrix returns the inverse of RGBToYUVMatrix.
+func YUVToRGBMatrix(cs ColorSpace) (Matrix3x3, error) {
+	m, err := RGBToYUVMatrix(cs)
+	if err != nil {
+		return Matrix3x3{}, err
+	}
+	return m.Inverse()
+}
+
+// yuvMatrices holds the forward and inverse matrices of every color space,
+// or the error RGBToYUVMatrix returned for it.
+var yuvMatrices [ColorSpaceNB]struct {
+	fwd, inv Matrix3x3
+	err      error
+}
+
+func init() {
+	for cs := range yuvMatrices {
+		m := &yuvMatrices[cs]
+		if m.fwd, m.err = RGBToYUVMatrix(ColorSpace(cs)); m.err == nil {
+			m.inv, m.err = m.fwd.Inverse()
+		}
+	}
+}
+
+// matricesOf returns the forward and inverse matrices of cs.
+// ColorSpaceUnspecified and ColorSpaceRGB use BT.601, which is also what
+// image/color assumes. Other color spaces without a matrix return the
+// RGBToYUVMatrix error.
+func matricesOf(cs ColorSpace) (fwd, inv Matrix3x3, err error) {
+	if cs == ColorSpaceUnspecified || cs == ColorSpaceRGB {
+		cs = ColorSpaceSMPTE170M
+	}
+	if cs < 0 || cs >= ColorSpaceNB {
+		return Matrix3x3{}, Matrix3x3{}, ErrInvalidArgument
+	}
+	m := &yuvMatrices[cs]
+	return m.fwd, m.inv, m.err
+}
+
+// ---------------- Range levels ----------------
+
+// RangeLevels describes how normalized luma in [0, 1] and chroma in
+// [-0.5, 0.5] map to integer codes: code = offset + scale * value.
+type RangeLevels struct {
+	Depth        int
+	LumaOffset   int
+	LumaScale    int
+	ChromaOffset int
+	ChromaScale  int
+}
+
+// ColorRangeLevels returns the levels of a color range at the given bit
+// depth.
+//
+// ColorRangeMPEG uses 16-235 luma and 16-240 chroma scaled up from 8 bits,
+// so it needs a depth of at least 8. ColorRangeJPEG spans the full code
+// range with chroma centred on half of it. Unspecified ranges and depths
+// outside of 1..32 return ErrInvalidArgument.
+func ColorRangeLevels(r ColorRange, depth int) (RangeLevels, error) {
+	if depth < 1 || depth > 32 {
+		return RangeLevels{}, ErrInvalidArgument
+	}
+	switch r {
+	case ColorRangeMPEG:
+		if depth < 8 {
+			return RangeLevels{}, ErrInvalidArgument
+		}
+		s := depth - 8
+		return RangeLevels{Depth: depth, LumaOffset: 16 << s,
+			LumaScale: 219 << s, ChromaOffset: 128 << s,
+			ChromaScale: 224 << s}, nil
+	case ColorRangeJPEG:
+		maxv := int(uint64(1)<<depth - 1)
+		return RangeLevels{Depth: depth, LumaScale: maxv,
+			ChromaOffset: 1 << (depth - 1), ChromaScale: maxv}, nil
+	}
+	return RangeLevels{}, ErrInvalidArgument
+}
+
+// Quantize returns the rounded code of a normalized luma value, or of a
+// chroma value when chroma is set, clamped to the codes of the bit depth.
+func (l RangeLevels) Quantize(v float64, chroma bool) int {
+	offset, scale := l.LumaOffset, l.LumaScale
+	if chroma {
+		offset, scale = l.ChromaOffset, l.ChromaScale
+	}
+	code := math.Round(float64(offset) + float64(scale)*v)
+	return int(math.Max(0, math.Min(code, float64(uint64(1)<<l.Depth-1))))
+}
+
+// Normalize is the inverse of Quantize, without clamping.
+func (l RangeLevels) Normalize(code int, chroma bool) float64 {
+	if chroma {
+		return float64(code-l.ChromaOffset) / float64(l.ChromaScale)
+	}
+	return float64(code-l.LumaOffset) / float64(l.LumaScale)
+}
+
+// levelsOf returns the full or limited range levels of a bit depth. Depths
+// too small for limited range use full range.
+func levelsOf(depth int, full bool) RangeLevels {
+	if !full {
+		if l, err := ColorRangeLevels(ColorRangeMPEG, depth); err == nil {
+			return l
+		}
+	}
+	l, _ := ColorRangeLevels(ColorRangeJPEG, depth)
+	return l
+}
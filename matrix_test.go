@@ -0,0 +1,70 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_RGBToYUVMatrix(t *testing.T) {
+	for _, cs := range []pixfmts.ColorSpace{pixfmts.ColorSpaceBT470BG,
+		pixfmts.ColorSpaceSMPTE170M, pixfmts.ColorSpaceBT709,
+		pixfmts.ColorSpaceSMPTE240M, pixfmts.ColorSpaceFCC,
+		pixfmts.ColorSpaceBT2020_NCL, pixfmts.ColorSpaceYCGCO,
+		pixfmts.ColorSpaceYCGCO_RE, pixfmts.ColorSpaceYCGCO_RO} {
+		fwd, err := pixfmts.RGBToYUVMatrix(cs)
+		if err != nil {
+			t.Fatalf("%v: %v", cs, err)
+		}
+		inv, err := pixfmts.YUVToRGBMatrix(cs)
+		if err != nil {
+			t.Fatalf("%v: %v", cs, err)
+		}
+
+		// White has full luma and no chroma, pure blue the largest Cb.
+		y, u, v := fwd.Apply(1, 1, 1)
+		if math.Abs(y-1) > 1e-9 || math.Abs(u) > 1e-9 || math.Abs(v) > 1e-9 {
+			t.Fatalf("%v: white is %v %v %v", cs, y, u, v)
+		}
+		r, g, b := inv.Apply(fwd.Apply(0.2, 0.4, 0.8))
+		if math.Abs(r-0.2)+math.Abs(g-0.4)+math.Abs(b-0.8) > 1e-9 {
+			t.Fatalf("%v: round trip gave %v %v %v", cs, r, g, b)
+		}
+	}
+
+	m, _ := pixfmts.RGBToYUVMatrix(pixfmts.ColorSpaceBT709)
+	if _, u, _ := m.Apply(0, 0, 1); math.Abs(u-0.5) > 1e-9 {
+		t.Fatalf("BT.709 blue has Cb %v", u)
+	}
+	if _, err := pixfmts.RGBToYUVMatrix(pixfmts.ColorSpaceICTCP); !errors.Is(
+		err, pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
+
+func Test_ColorRangeLevels(t *testing.T) {
+	l, err := pixfmts.ColorRangeLevels(pixfmts.ColorRangeMPEG, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Quantize(0, false) != 64 || l.Quantize(1, false) != 940 ||
+		l.Quantize(0.5, true) != 960 || l.Quantize(-2, true) != 0 {
+		t.Fatalf("unexpected limited range codes: %+v", l)
+	}
+
+	l, err = pixfmts.ColorRangeLevels(pixfmts.ColorRangeJPEG, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Quantize(1, false) != 255 || l.Quantize(0, true) != 128 ||
+		l.Normalize(255, false) != 1 {
+		t.Fatalf("unexpected full range codes: %+v", l)
+	}
+
+	if _, err := pixfmts.ColorRangeLevels(pixfmts.ColorRangeMPEG,
+		4); !errors.Is(err, pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package gopixfmts
+
+import (
+	"fmt"
+	"math"
+)
+
+// ComponentMetrics holds the quality metrics of one component of a frame
+// compared against a reference.
+type ComponentMetrics struct {
+	Component int
+	Plane     int // Plane of the component in the first frame.
+
+	// Peak is the largest sample value: 2^depth-1 for integer components,
+	// or 1 for floating point components and normalized comparisons.
+	Peak float64
+
+	// MSE is the mean squared error in units of the samples compared. PSNR
+	// is 10*log10(Peak²/MSE), +Inf for identical components.
+	MSE  float64
+	PSNR float64
+
+	// SSIM is the mean structural similarity over 8x8 windows stepped by 4
+	// samples, as computed by FFmpeg's ssim filter. Components smaller than
+	// a window are compared as a whole.
+	SSIM float64
+}
+
+// FrameMetrics holds the per component and overall quality metrics of a
+// frame compared against a reference.
+type FrameMetrics struct {
+	Components []ComponentMetrics
+
+	// MSE is the weighted mean of every component's MSE divided by its
+	// squared peak, so components of different depths weigh alike. PSNR is
+	// derived from it with a peak of 1 and SSIM is the weighted mean of the
+	// component SSIMs.
+	MSE  float64
+	PSNR float64
+	SSIM float64
+}
+
+// MetricsOptions tunes CompareFrames.
+type MetricsOptions struct {
+	// Weights sets the weight of every component in the overall metrics.
+	// When all are zero, components weigh by their number of samples, as
+	// in FFmpeg's psnr filter, so chroma counts for less in 4:2:0 formats.
+	Weights [4]float64
+
+	// Normalize allows frames of different pixel formats to be compared.
+	// Every sample is read with ReadImageLine and scaled to [0, 1] by its
+	// component's depth before comparing, so a yuv420p frame and its
+	// yuv420p10le counterpart compare closely. Range, matrix and component
+	// order differences are not accounted for.
+	Normalize bool
+}
+
+// CompareFrames computes the MSE, PSNR and SSIM of every component of dist
+// against ref, along with weighted overall values.
+//
+// Both frames must have the same size. Unless opts.Normalize is set they
+// must share the pixel format, and samples are compared as stored, giving
+// PSNR values with the depth-correct peak. Normalized comparisons need the
+// formats to agree on the number of components and the chroma subsampling.
+// Mismatches, paletted and hardware accelerated formats return
+// ErrInvalidArgument. A nil opts uses the defaults.
+func CompareFrames(ref, dist *Frame, opts *MetricsOptions) (*FrameMetrics,
+	error) {
+	if opts == nil {
+		opts = &MetricsOptions{}
+	}
+	if ref == nil || dist == nil || ref.width != dist.width ||
+		ref.height != dist.height {
+		return nil, fmt.Errorf("%w: frames differ in size", ErrInvalidArgument)
+	}
+	if !opts.Normalize && ref.format != dist.format {
+		return nil, fmt.Errorf("%w: %s and %s differ; set Normalize",
+			ErrInvalidArgument, GetPixFmtName(ref.format),
+			GetPixFmtName(dist.format))
+	}
+	n := ref.desc.NbComponents()
+	if n != dist.desc.NbComponents() ||
+		ref.desc.Log2ChromaW() != dist.desc.Log2ChromaW() ||
+		ref.desc.Log2ChromaH() != dist.desc.Log2ChromaH() {
+		return nil, fmt.Errorf("%w: component layouts differ",
+			ErrInvalidArgument)
+	}
+	for _, d := range []*PixFmtDescRef{ref.desc, dist.desc} {
+		if d.Flags().Has(PixFmtFlagPAL) || d.Flags().Has(PixFmtFlagHWAccel) {
+			return nil, fmt.Errorf("%w: cannot compare %s", ErrInvalidArgument,
+				d.Name())
+		}
+	}
+
+	m := &FrameMetrics{Components: make([]ComponentMetrics, n)}
+	weights := opts.Weights
+	if weights == ([4]float64{}) {
+		for c := 0; c < n; c++ {
+			w, h := componentSize(ref.desc, c, ref.width, ref.height)
+			weights[c] = float64(w * h)
+		}
+	}
+	total := 0.0
+	for c := 0; c < n; c++ {
+		total += weights[c]
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("%w: weights sum to %g", ErrInvalidArgument,
+			total)
+	}
+
+	for c := 0; c < n; c++ {
+		a, peak, err := metricSamples(ref, c, opts.Normalize)
+		if err != nil {
+			return nil, err
+		}
+		b, _, err := metricSamples(dist, c, opts.Normalize)
+		if err != nil {
+			return nil, err
+		}
+		comp, _ := ref.desc.Component(c)
+		w, h := componentSize(ref.desc, c, ref.width, ref.height)
+		cm := ComponentMetrics{Component: c, Plane: comp.Plane, Peak: peak,
+			MSE: meanSquaredError(a, b), SSIM: ssimPlane(a, b, w, h, peak)}
+		cm.PSNR = psnr(cm.MSE, peak)
+		m.Components[c] = cm
+
+		wt := weights[c] / total
+		m.MSE += wt * cm.MSE / (peak * peak)
+		m.SSIM += wt * cm.SSIM
+	}
+	m.PSNR = psnr(m.MSE, 1)
+	return m, nil
+}
+
+// metricSamples reads component c of f as float64 samples along with their
+// peak. Floating point samples, and every sample when normalize is set, are
+// scaled to [0, 1].
+func metricSamples(f *Frame, c int, normalize bool) ([]float64, float64,
+	error) {
+	s, err := ReadComponent32(f, c)
+	if err != nil {
+		return nil, 0, err
+	}
+	comp, _ := f.desc.Component(c)
+	float := f.desc.IsFloat()
+	peak := float64(uint64(1)<<comp.Depth - 1)
+	out := make([]float64, len(s.Samples))
+	for i, v := range s.Samples {
+		switch {
+		case float || normalize:
+			out[i] = decodeNormalized(comp.Depth, float, v)
+		default:
+			out[i] = float64(v)
+		}
+	}
+	if float || normalize {
+		peak = 1
+	}
+	return out, peak, nil
+}
+
+// meanSquaredError returns the mean squared difference of a and b.
+func meanSquaredError(a, b []float64) float64 {
+	if len(a) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum / float64(len(a))
+}
+
+// psnr returns the peak signal to noise ratio in dB of the given MSE.
+func psnr(mse, peak float64) float64 {
+	if mse == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(peak*peak/mse)
+}
+
+// ssimSums holds the sums over a window that SSIM is computed from.
+type ssimSums struct {
+	a, b, sq, ab float64
+}
+
+func (s *ssimSums) add(o ssimSums) {
+	s.a += o.a
+	s.b += o.b
+	s.sq += o.sq
+	s.ab += o.ab
+}
+
+// ssim returns the structural similarity of a window of n samples, following
+// ssim_end1 of FFmpeg's ssim filter. Like FFmpeg and x264, C1 is scaled by n
+// rather than n², which weighs it less than the textbook formula.
+func (s ssimSums) ssim(n, peak float64) float64 {
+	c1 := 0.01 * 0.01 * peak * peak * n
+	c2 := 0.03 * 0.03 * peak * peak * n * (n - 1)
+	vars := s.sq*n - s.a*s.a - s.b*s.b
+	covar := s.ab*n - s.a*s.b
+	return (2*s.a*s.b + c1) * (2*covar + c2) /
+		((s.a*s.a + s.b*s.b + c1) * (vars + c2))
+}
+
+// ssimPlane returns the mean SSIM of two w x h planes over 8x8 windows
+// stepped by 4 samples. Planes smaller than a window are one window.
+func ssimPlane(a, b []float64, w, h int, peak float64) float64 {
+	sums := func(x0, y0, bw, bh int) ssimSums {
+		var s ssimSums
+		for y := y0; y < y0+bh; y++ {
+			for x := x0; x < x0+bw; x++ {
+				va, vb := a[y*w+x], b[y*w+x]
+				s.add(ssimSums{va, vb, va*va + vb*vb, va * vb})
+			}
+		}
+		return s
+	}
+	if w < 8 || h < 8 {
+		if w == 0 || h == 0 {
+			return 1
+		}
+		return sums(0, 0, w, h).ssim(float64(w*h), peak)
+	}
+
+	// Sum 4x4 blocks once, then combine 2x2 groups of them into windows.
+	bw, bh := w/4, h/4
+	blocks := make([]ssimSums, bw*bh)
+	for by := 0; by < bh; by++ {
+		for bx := 0; bx < bw; bx++ {
+			blocks[by*bw+bx] = sums(bx*4, by*4, 4, 4)
+		}
+	}
+	total := 0.0
+	for by := 0; by < bh-1; by++ {
+		for bx := 0; bx < bw-1; bx++ {
+			var s ssimSums
+			for _, i := range []int{by*bw + bx, by*bw + bx + 1,
+				(by+1)*bw + bx, (by+1)*bw + bx + 1} {
+				s.add(blocks[i])
+			}
+			total += s.ssim(64, peak)
+		}
+	}
+	return total / float64((bw-1)*(bh-1))
+}
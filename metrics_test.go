@@ -0,0 +1,104 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_CompareFrames(t *testing.T) {
+	ref := newFrame(t, pixfmts.PixFmtYUV420P, 16, 16)
+	rng := rand.New(rand.NewSource(8))
+	for _, p := range ref.Planes() {
+		rng.Read(p)
+	}
+	m, err := pixfmts.CompareFrames(ref, ref, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !math.IsInf(m.PSNR, 1) || math.Abs(m.SSIM-1) > 1e-12 || m.MSE != 0 {
+		t.Fatalf("identical frames: %+v", m)
+	}
+
+	// Disturb luma and leave chroma untouched.
+	dist := newFrame(t, pixfmts.PixFmtYUV420P, 16, 16)
+	if err := pixfmts.Convert(dist, ref); err != nil {
+		t.Fatal(err)
+	}
+	for y := range 16 {
+		row := dist.Planes()[0][y*dist.Linesizes()[0]:][:16]
+		for x := range row {
+			row[x] = ref.Planes()[0][y*ref.Linesizes()[0]+x] ^ 10
+		}
+	}
+	m, err = pixfmts.CompareFrames(ref, dist, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	luma := m.Components[0]
+	if luma.Peak != 255 || luma.SSIM >= 1 || luma.SSIM <= 0 {
+		t.Fatalf("unexpected luma metrics %+v", luma)
+	}
+	if !math.IsInf(m.Components[1].PSNR, 1) {
+		t.Fatalf("chroma changed: %+v", m.Components[1])
+	}
+	want := luma.MSE / (255 * 255) * 256 / 384
+	if math.Abs(m.MSE-want) > 1e-12 {
+		t.Fatalf("overall MSE %g, want %g", m.MSE, want)
+	}
+	if math.Abs(luma.PSNR-10*math.Log10(255*255/luma.MSE)) > 1e-9 {
+		t.Fatalf("unexpected PSNR %g for MSE %g", luma.PSNR, luma.MSE)
+	}
+}
+
+func Test_CompareFrames_Normalize(t *testing.T) {
+	ref := newFrame(t, pixfmts.PixFmtYUV420P, 8, 8)
+	rng := rand.New(rand.NewSource(9))
+	for _, p := range ref.Planes() {
+		rng.Read(p)
+	}
+	deep := newFrame(t, pixfmts.PixFmtYUV420P10LE, 8, 8)
+	if err := pixfmts.Convert(deep, ref); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pixfmts.CompareFrames(ref, deep, nil); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	m, err := pixfmts.CompareFrames(ref, deep,
+		&pixfmts.MetricsOptions{Normalize: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.PSNR < 50 || m.SSIM < 0.999 {
+		t.Fatalf("unexpected metrics %+v", m)
+	}
+	if m.Components[0].Peak != 1 {
+		t.Fatalf("unexpected peak %g", m.Components[0].Peak)
+	}
+}
+
+func Test_CompareFrames_SSIMKnownValue(t *testing.T) {
+	// Flat dark frames are one 8x8 window. Following ssim_end1 of FFmpeg's
+	// ssim filter with sums s1 = 1024, s2 = 1280, no variance and
+	// C1 = 416, the SSIM is (2*1024*1280 + 416) / (1024² + 1280² + 416).
+	fill := func(v byte) *pixfmts.Frame {
+		f := newFrame(t, pixfmts.PixFmtGray8, 8, 8)
+		for i := range f.Planes()[0] {
+			f.Planes()[0][i] = v
+		}
+		return f
+	}
+	m, err := pixfmts.CompareFrames(fill(16), fill(20), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = 2621856.0 / 2687392
+	if got := m.Components[0].SSIM; math.Abs(got-want) > 1e-6 {
+		t.Fatalf("SSIM %.7f, want %.7f", got, want)
+	}
+}
@@ -0,0 +1,270 @@
+package gopixfmts
+
+import "encoding/binary"
+
+// ---------------- Native image line read/write ----------------
+
+// ReadImageLineNative is a pure-Go implementation of ReadImageLine.
+//
+// It follows av_read_image_line2 bit for bit but runs without a cgo call, so
+// it is considerably cheaper when a frame is inspected line by line. The
+// arguments and errors are the same as for ReadImageLine.
+//
+// Like libavutil, the 10-bit bitstream formats such as xv30be and v30xbe
+// ignore x and always start at the beginning of the row.
+func ReadImageLineNative(dst []uint16, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c, w int, readPalComponent bool) error {
+	if desc == nil {
+		return ErrInvalidArgument
+	}
+	if err := checkLineBuffer(len(dst), w, 1); err != nil {
+		return err
+	}
+	err := checkImageLine(data, linesize, desc, x, y, c, w, readPalComponent)
+	if err != nil || w == 0 {
+		return err
+	}
+	readLine(dst[:w], data, linesize, desc, x, y, c, readPalComponent)
+	return nil
+}
+
+// ReadImageLine2Native is a pure-Go implementation of ReadImageLine2.
+//
+// Samples are stored in dst as native-endian integers of dstElementSize
+// bytes, which must be 2 or 4. The arguments and errors are the same as for
+// ReadImageLine2.
+func ReadImageLine2Native(dst []byte, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c, w int, readPalComponent bool,
+	dstElementSize int) error {
+	if desc == nil {
+		return ErrInvalidArgument
+	}
+	if dstElementSize != 2 && dstElementSize != 4 {
+		return ErrInvalidArgument
+	}
+	if err := checkLineBuffer(len(dst), w, dstElementSize); err != nil {
+		return err
+	}
+	err := checkImageLine(data, linesize, desc, x, y, c, w, readPalComponent)
+	if err != nil || w == 0 {
+		return err
+	}
+	if dstElementSize == 2 {
+		line := make([]uint16, w)
+		readLine(line, data, linesize, desc, x, y, c, readPalComponent)
+		for i, v := range line {
+			binary.NativeEndian.PutUint16(dst[2*i:], v)
+		}
+		return nil
+	}
+	line := make([]uint32, w)
+	readLine(line, data, linesize, desc, x, y, c, readPalComponent)
+	for i, v := range line {
+		binary.NativeEndian.PutUint32(dst[4*i:], v)
+	}
+	return nil
+}
+
+// WriteImageLineNative is a pure-Go implementation of WriteImageLine.
+//
+// Like av_write_image_line2, samples are OR-ed into the destination, so the
+// bits of the component being written should be clear beforehand. The
+// arguments and errors are the same as for WriteImageLine.
+func WriteImageLineNative(src []uint16, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c, w int) error {
+	if desc == nil {
+		return ErrInvalidArgument
+	}
+	if err := checkLineBuffer(len(src), w, 1); err != nil {
+		return err
+	}
+	err := checkImageLine(data, linesize, desc, x, y, c, w, false)
+	if err != nil || w == 0 {
+		return err
+	}
+	writeLine(src[:w], data, linesize, desc, x, y, c, false)
+	return nil
+}
+
+// WriteImageLine2Native is a pure-Go implementation of WriteImageLine2.
+//
+// Samples are read from src as native-endian integers of srcElementSize
+// bytes, which must be 2 or 4. The arguments and errors are the same as for
+// WriteImageLine2.
+func WriteImageLine2Native(src []byte, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c, w int, srcElementSize int) error {
+	if desc == nil {
+		return ErrInvalidArgument
+	}
+	if srcElementSize != 2 && srcElementSize != 4 {
+		return ErrInvalidArgument
+	}
+	if err := checkLineBuffer(len(src), w, srcElementSize); err != nil {
+		return err
+	}
+	err := checkImageLine(data, linesize, desc, x, y, c, w, false)
+	if err != nil || w == 0 {
+		return err
+	}
+	if srcElementSize == 2 {
+		line := make([]uint16, w)
+		for i := range line {
+			line[i] = binary.NativeEndian.Uint16(src[2*i:])
+		}
+		writeLine(line, data, linesize, desc, x, y, c, false)
+		return nil
+	}
+	line := make([]uint32, w)
+	for i := range line {
+		line[i] = binary.NativeEndian.Uint32(src[4*i:])
+	}
+	writeLine(line, data, linesize, desc, x, y, c, false)
+	return nil
+}
+
+// sample is the element type of a line buffer.
+type sample interface{ ~uint16 | ~uint32 }
+
+// byteOrder returns the byte order of multi-byte components of desc.
+func byteOrder(desc *PixFmtDescRef) binary.ByteOrder {
+	if desc.Flags().Has(PixFmtFlagBigEndian) {
+		return binary.BigEndian
+	}
+	return binary.LittleEndian
+}
+
+// readLine is the body of av_read_image_line2. The arguments must have been
+// validated by checkImageLine.
+func readLine[T sample](dst []T, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c int, readPal bool) {
+	comp, _ := desc.Component(c)
+	mask := uint32(1<<comp.Depth - 1)
+	plane := data[comp.Plane]
+	row := y * linesize[comp.Plane]
+	order := byteOrder(desc)
+
+	if desc.Flags().Has(PixFmtFlagBitstream) {
+		if comp.Depth == 10 {
+			// All components are packed into one 32-bit big-endian word.
+			// Like libavutil, x is ignored and reading starts at the row.
+			for i := range dst {
+				p := row + 4*i
+				val := binary.BigEndian.Uint32(plane[p:]) >> comp.Offset & mask
+				if readPal {
+					val = uint32(data[1][4*val+uint32(c)])
+				}
+				dst[i] = T(val)
+			}
+			return
+		}
+		skip := x*comp.Step + comp.Offset
+		p := row + skip>>3
+		shift := 8 - comp.Depth - skip&7
+		for i := range dst {
+			val := uint32(plane[p]) >> shift & mask
+			if readPal {
+				val = uint32(data[1][4*val+uint32(c)])
+			}
+			shift -= comp.Step
+			p -= shift >> 3
+			shift &= 7
+			dst[i] = T(val)
+		}
+		return
+	}
+
+	p := row + x*comp.Step + comp.Offset
+	is8bit := comp.Shift+comp.Depth <= 8
+	is16bit := comp.Shift+comp.Depth <= 16
+	if is8bit && desc.Flags().Has(PixFmtFlagBigEndian) {
+		p++
+	}
+	for i := range dst {
+		var val uint32
+		switch {
+		case is8bit:
+			val = uint32(plane[p])
+		case is16bit:
+			val = uint32(order.Uint16(plane[p:]))
+		default:
+			val = order.Uint32(plane[p:])
+		}
+		val = val >> comp.Shift & mask
+		if readPal {
+			val = uint32(data[1][4*val+uint32(c)])
+		}
+		p += comp.Step
+		dst[i] = T(val)
+	}
+}
+
+// writeLine is the body of av_write_image_line2. The arguments must have
+// been validated by checkImageLine.
+//
+// Like libavutil, samples are OR-ed into place. With overwrite set the bits
+// of the component are cleared first and samples are masked to its depth,
+// so existing values are replaced and neighbouring bits are left untouched.
+func writeLine[T sample](src []T, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c int, overwrite bool) {
+	comp, _ := desc.Component(c)
+	clear, limit := uint32(0), ^uint32(0)
+	if overwrite {
+		clear = uint32(1<<comp.Depth - 1)
+		limit = clear
+	}
+	plane := data[comp.Plane]
+	row := y * linesize[comp.Plane]
+	order := byteOrder(desc)
+
+	if desc.Flags().Has(PixFmtFlagBitstream) {
+		if comp.Depth == 10 {
+			// All components are packed into one 32-bit big-endian word.
+			// As in readLine, x is ignored.
+			mask := uint32(1<<comp.Depth-1) << comp.Offset
+			for i, s := range src {
+				p := plane[row+4*i:]
+				val := uint32(uint16(s)) & limit << comp.Offset
+				binary.BigEndian.PutUint32(p,
+					binary.BigEndian.Uint32(p)&^mask|val)
+			}
+			return
+		}
+		skip := x*comp.Step + comp.Offset
+		p := row + skip>>3
+		shift := 8 - comp.Depth - skip&7
+		for _, s := range src {
+			plane[p] = plane[p]&^byte(clear<<shift) | byte(uint32(s)&limit<<shift)
+			shift -= comp.Step
+			p -= shift >> 3
+			shift &= 7
+		}
+		return
+	}
+
+	p := row + x*comp.Step + comp.Offset
+	switch {
+	case comp.Shift+comp.Depth <= 8:
+		if desc.Flags().Has(PixFmtFlagBigEndian) {
+			p++
+		}
+		for _, s := range src {
+			plane[p] = plane[p]&^byte(clear<<comp.Shift) |
+				byte(uint32(s)&limit<<comp.Shift)
+			p += comp.Step
+		}
+	case comp.Shift+comp.Depth <= 16:
+		for _, s := range src {
+			val := order.Uint16(plane[p:])&^uint16(clear<<comp.Shift) |
+				uint16(uint32(s)&limit<<comp.Shift)
+			order.PutUint16(plane[p:], val)
+			p += comp.Step
+		}
+	default:
+		for _, s := range src {
+			val := order.Uint32(plane[p:])&^(clear<<comp.Shift) |
+				uint32(s)&limit<<comp.Shift
+			order.PutUint32(plane[p:], val)
+			p += comp.Step
+		}
+	}
+}
@@ -0,0 +1,218 @@
+//go:build cgo
+
+package gopixfmts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// Test_NativeImageLine_MatchLibavutil reads and writes every component of
+// every pixel format through both libavutil and the native implementation
+// and requires bit-exact results. Overwriting writes, which libavutil lacks,
+// are checked by reading them back through libavutil.
+func Test_NativeImageLine_MatchLibavutil(t *testing.T) {
+	const width, height = 13, 5
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for desc := range AllPixFmtDescs() {
+		if desc.IsHWAccel() || desc.NbComponents() == 0 {
+			continue
+		}
+		pf, _ := desc.PixFmtDescID()
+		frame, err := NewFrame(pf, width, height, 1)
+		if err != nil {
+			t.Fatalf("%s: %v", desc.Name(), err)
+		}
+		for _, plane := range frame.data {
+			for i := range plane {
+				plane[i] = byte(rng.Uint32())
+			}
+		}
+
+		for c := 0; c < desc.NbComponents(); c++ {
+			comp, _ := desc.Component(c)
+			w, h := componentSize(desc, c, width, height)
+			for y := 0; y < h; y++ {
+				for _, x := range []int{0, 1} {
+					n := w - x
+					// Some descriptors, such as uyyvyy411's, address bytes
+					// past their last lines, which both sides refuse.
+					err := checkImageLine(frame.data, frame.linesize, desc,
+						x, y, c, n, false)
+					if errors.Is(err, ErrOutOfBounds) {
+						continue
+					}
+					for _, size := range []int{2, 4} {
+						for _, pal := range []bool{false, desc.IsPaletted()} {
+							want := make([]byte, n*size)
+							got := make([]byte, n*size)
+							err := ReadImageLine2(want, frame.data,
+								frame.linesize, desc, x, y, c, n, pal, size)
+							if err != nil {
+								t.Fatalf("%s: %v", desc.Name(), err)
+							}
+							err = ReadImageLine2Native(got, frame.data,
+								frame.linesize, desc, x, y, c, n, pal, size)
+							if err != nil {
+								t.Fatalf("%s: %v", desc.Name(), err)
+							}
+							if !bytes.Equal(got, want) {
+								t.Fatalf("%s: read c=%d x=%d y=%d size=%d "+
+									"pal=%v: got %v, want %v", desc.Name(), c,
+									x, y, size, pal, got, want)
+							}
+						}
+					}
+
+					mask := uint32(1)<<min(comp.Depth, 16) - 1
+					src := make([]uint16, n)
+					for i := range src {
+						src[i] = uint16(rng.Uint32() & mask)
+					}
+					want := clonePlanes(frame.data)
+					got := clonePlanes(frame.data)
+					err = WriteImageLine(src, want, frame.linesize, desc, x,
+						y, c, n)
+					if err != nil {
+						t.Fatalf("%s: %v", desc.Name(), err)
+					}
+					err = WriteImageLineNative(src, got, frame.linesize, desc,
+						x, y, c, n)
+					if err != nil {
+						t.Fatalf("%s: %v", desc.Name(), err)
+					}
+					for p := range want {
+						if !bytes.Equal(got[p], want[p]) {
+							t.Fatalf("%s: write c=%d x=%d y=%d differs in "+
+								"plane %d", desc.Name(), c, x, y, p)
+						}
+					}
+
+					checkWriteLine32(t, rng, frame, c, x, y, n)
+					checkOverwriteLine(t, rng, frame, c, x, y, n)
+				}
+			}
+		}
+	}
+}
+
+// checkWriteLine32 compares WriteImageLine2Native with WriteImageLine2 for
+// 4-byte samples covering the full depth of component c.
+func checkWriteLine32(t *testing.T, rng *rand.Rand, frame *Frame, c, x, y,
+	n int) {
+	t.Helper()
+	desc := frame.desc
+	comp, _ := desc.Component(c)
+	mask := uint32(uint64(1)<<comp.Depth - 1)
+	src := make([]byte, 4*n)
+	for i := 0; i < n; i++ {
+		binary.NativeEndian.PutUint32(src[4*i:], rng.Uint32()&mask)
+	}
+	want := clonePlanes(frame.data)
+	got := clonePlanes(frame.data)
+	err := WriteImageLine2(src, want, frame.linesize, desc, x, y, c, n, 4)
+	if err != nil {
+		t.Fatalf("%s: %v", desc.Name(), err)
+	}
+	err = WriteImageLine2Native(src, got, frame.linesize, desc, x, y, c, n, 4)
+	if err != nil {
+		t.Fatalf("%s: %v", desc.Name(), err)
+	}
+	for p := range want {
+		if !bytes.Equal(got[p], want[p]) {
+			t.Fatalf("%s: 32-bit write c=%d x=%d y=%d differs in plane %d",
+				desc.Name(), c, x, y, p)
+		}
+	}
+}
+
+// checkOverwriteLine overwrites component c with unmasked samples and
+// requires libavutil to read back the samples masked to the component's
+// depth, with every other component unchanged.
+func checkOverwriteLine(t *testing.T, rng *rand.Rand, frame *Frame, c, x,
+	y, n int) {
+	t.Helper()
+	desc := frame.desc
+	comp, _ := desc.Component(c)
+	mask := uint32(uint64(1)<<comp.Depth - 1)
+	src := make([]uint32, n)
+	for i := range src {
+		src[i] = rng.Uint32()
+	}
+	data := clonePlanes(frame.data)
+	writeLine(src, data, frame.linesize, desc, x, y, c, true)
+
+	line := make([]byte, 4*n)
+	err := ReadImageLine2(line, data, frame.linesize, desc, x, y, c, n,
+		false, 4)
+	if err != nil {
+		t.Fatalf("%s: %v", desc.Name(), err)
+	}
+	for i, s := range src {
+		if v := binary.NativeEndian.Uint32(line[4*i:]); v != s&mask {
+			t.Fatalf("%s: overwrite c=%d x=%d y=%d sample %d: got %#x, "+
+				"want %#x", desc.Name(), c, x, y, i, v, s&mask)
+		}
+	}
+
+	// Components whose bits libavutil itself lets the write reach, as in
+	// Bayer formats or uyyvyy411, cannot stay unchanged.
+	probe := clonePlanes(frame.data)
+	for _, p := range probe {
+		clear(p)
+	}
+	ones := make([]byte, 4*n)
+	for i := 0; i < n; i++ {
+		binary.NativeEndian.PutUint32(ones[4*i:], mask)
+	}
+	err = WriteImageLine2(ones, probe, frame.linesize, desc, x, y, c, n, 4)
+	if err != nil {
+		t.Fatalf("%s: %v", desc.Name(), err)
+	}
+
+	for o := 0; o < desc.NbComponents(); o++ {
+		if o == c {
+			continue
+		}
+		w, h := componentSize(desc, o, frame.width, frame.height)
+		want := make([]byte, 4*w)
+		got := make([]byte, 4*w)
+		reached := make([]byte, 4*w)
+		for oy := 0; oy < h; oy++ {
+			err := checkImageLine(data, frame.linesize, desc, 0, oy, o, w,
+				false)
+			if errors.Is(err, ErrOutOfBounds) {
+				continue
+			}
+			for _, l := range []struct {
+				dst  []byte
+				data [4][]byte
+			}{{want, frame.data}, {got, data}, {reached, probe}} {
+				err := ReadImageLine2(l.dst, l.data, frame.linesize, desc, 0,
+					oy, o, w, false, 4)
+				if err != nil {
+					t.Fatalf("%s: %v", desc.Name(), err)
+				}
+			}
+			if slices.ContainsFunc(reached, func(b byte) bool { return b != 0 }) {
+				continue
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("%s: overwrite c=%d x=%d y=%d changed component %d",
+					desc.Name(), c, x, y, o)
+			}
+		}
+	}
+}
+
+func clonePlanes(data [4][]byte) (out [4][]byte) {
+	for i, p := range data {
+		out[i] = slices.Clone(p)
+	}
+	return out
+}
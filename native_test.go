@@ -0,0 +1,96 @@
+package gopixfmts_test
+
+import (
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_ImageLineNative_RGB565(t *testing.T) {
+	rgb565be, err := pixfmts.PixFmtDescGet(pixfmts.PixFmtRGB565BE)
+	if err != nil {
+		t.FailNow()
+	}
+
+	data := [4][]byte{make([]byte, 4)}
+	linesize := [4]int{4}
+	for c, v := range []uint16{0x1f, 0x2a, 0x05} {
+		err := pixfmts.WriteImageLineNative([]uint16{v, v}, data, linesize,
+			rgb565be, 0, 0, c, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if data[0][0] != 0xfd || data[0][1] != 0x45 || data[0][2] != 0xfd {
+		t.Fatalf("unexpected packed pixels: % x", data[0])
+	}
+
+	dst := make([]uint16, 2)
+	err = pixfmts.ReadImageLineNative(dst, data, linesize, rgb565be, 1, 0, 1,
+		1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst[0] != 0x2a {
+		t.Fatalf("unexpected green sample: %#x", dst[0])
+	}
+}
+
+func Test_ImageLineNative_MonoBlack(t *testing.T) {
+	monob, err := pixfmts.PixFmtDescGet(pixfmts.PixFmtMonoBlack)
+	if err != nil {
+		t.FailNow()
+	}
+
+	data := [4][]byte{make([]byte, 2)}
+	linesize := [4]int{2}
+	src := []uint16{1, 0, 1, 1, 0, 0, 0, 0, 0, 1}
+	err = pixfmts.WriteImageLineNative(src, data, linesize, monob, 0, 0, 0,
+		len(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[0][0] != 0xb0 || data[0][1] != 0x40 {
+		t.Fatalf("unexpected bits: % x", data[0])
+	}
+
+	dst := make([]uint16, 3)
+	err = pixfmts.ReadImageLineNative(dst, data, linesize, monob, 7, 0, 0, 3,
+		false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst[0] != 0 || dst[1] != 0 || dst[2] != 1 {
+		t.Fatalf("unexpected samples: %v", dst)
+	}
+}
+
+func Test_ImageLineNative_XV30BE(t *testing.T) {
+	xv30be, err := pixfmts.PixFmtDescGet(pixfmts.PixFmtXV30BE)
+	if err != nil {
+		t.FailNow()
+	}
+
+	// Luma is bits 10..19 of each big-endian word. Like libavutil, x is
+	// ignored, so reading or writing at x == 1 touches the first pixel.
+	data := [4][]byte{{0x00, 0x05, 0x54, 0x00, 0x00, 0x0a, 0xa8, 0x00}}
+	linesize := [4]int{8}
+	dst := make([]uint16, 1)
+	err = pixfmts.ReadImageLineNative(dst, data, linesize, xv30be, 1, 0, 0,
+		1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dst[0] != 0x155 {
+		t.Fatalf("unexpected luma sample: %#x", dst[0])
+	}
+
+	err = pixfmts.WriteImageLineNative([]uint16{0x3ff}, data, linesize,
+		xv30be, 1, 0, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[0][1] != 0x0f || data[0][2] != 0xfc || data[0][5] != 0x0a {
+		t.Fatalf("unexpected packed pixels: % x", data[0])
+	}
+}
@@ -1,19 +1,19 @@
+//go:build cgo
+
 package gopixfmts
 
 /*
 #cgo pkg-config: libavutil
+#include <libavutil/error.h>
 #include <libavutil/pixdesc.h>
 #include <stdlib.h>
 */
 import "C"
 import (
-	"errors"
 	"fmt"
 	"unsafe"
 )
 
-type PixFmtFlag int
-
 const (
 	PixFmtFlagBigEndian PixFmtFlag = C.AV_PIX_FMT_FLAG_BE        // Pixel format is big-endian.
 	PixFmtFlagPAL       PixFmtFlag = C.AV_PIX_FMT_FLAG_PAL       // Pixel format has a palette in data[1], values are indexes in this palette.
@@ -34,21 +34,6 @@ type PixFmtDescRef struct {
 	cptr *C.AVPixFmtDescriptor
 }
 
-// ComponentDescriptor is a Descriptor of one of the 4 planes within a
-// PixFmtDescRef.
-type ComponentDescriptor struct {
-	Plane  int
-	Step   int
-	Offset int
-	Shift  int
-	Depth  int
-}
-
-var (
-	ErrUnknownPixelFormat = errors.New("unknown pixel format")
-	ErrInvalidArgument    = errors.New("invalid argument")
-)
-
 // ---------------- Descriptor acquisition ----------------
 
 // PixFmtDescGet returns a description of the given pixel format.
@@ -158,11 +143,11 @@ func (r *PixFmtDescRef) Log2ChromaH() int {
 // The flags indicate characteristics such as whether the format contains an
 // alpha channel, is planar or packed, or other structural traits. A nil
 // descriptor returns 0, meaning no flags are set.
-func (r *PixFmtDescRef) Flags() uint64 {
+func (r *PixFmtDescRef) Flags() PixFmtFlag {
 	if r == nil || r.cptr == nil {
 		return 0
 	}
-	return uint64(r.cptr.flags)
+	return PixFmtFlag(r.cptr.flags)
 }
 
 // Component returns the descriptor for the i-th color component of this pixel
@@ -178,7 +163,8 @@ func (r *PixFmtDescRef) Component(i int) (ComponentDescriptor, error) {
 		return zero, ErrInvalidArgument
 	}
 	if i < 0 || i >= 4 {
-		return zero, fmt.Errorf("component index out of range: %d", i)
+		return zero, fmt.Errorf("%w: component index out of range: %d",
+			ErrInvalidArgument, i)
 	}
 	ccomp := r.cptr.comp[i]
 	cd := ComponentDescriptor{
@@ -240,13 +226,15 @@ func GetPaddedBitsPerPixel(r *PixFmtDescRef) (int, error) {
 // hShift == 1 and vShift == 1, meaning chroma has half the resolution
 // horizontally and vertically.
 //
-// If the pixel format is invalid, an error is returned.
+// If the pixel format is invalid, an error wrapping ErrUnknownPixelFormat
+// and the libavutil AVError is returned.
 func PixFmtGetChromaSubSample(pf PixelFormat) (hShift int, vShift int, err error) {
 	var ch C.int
 	var cv C.int
 	ret := C.av_pix_fmt_get_chroma_sub_sample(cPixelFormat(pf), &ch, &cv)
 	if ret < 0 {
-		return 0, 0, fmt.Errorf("av_pix_fmt_get_chroma_sub_sample error: %d", int(ret))
+		return 0, 0, avErrorf(ErrUnknownPixelFormat, int(ret), "%d",
+			int(pf))
 	}
 	return int(ch), int(cv), nil
 }
@@ -257,11 +245,12 @@ func PixFmtGetChromaSubSample(pf PixelFormat) (hShift int, vShift int, err error
 // For example, packed RGB formats use a single plane, planar YUV formats often
 // use three planes, and certain specialized formats may have more.
 //
-// If the pixel format is invalid, an error is returned.
+// If the pixel format is invalid, an error wrapping ErrUnknownPixelFormat
+// and the libavutil AVError is returned.
 func PixFmtCountPlanes(pf PixelFormat) (int, error) {
 	ret := C.av_pix_fmt_count_planes(cPixelFormat(pf))
 	if ret < 0 {
-		return 0, fmt.Errorf("av_pix_fmt_count_planes error: %d", int(ret))
+		return 0, avErrorf(ErrUnknownPixelFormat, int(ret), "%d", int(pf))
 	}
 	return int(ret), nil
 }
@@ -286,7 +275,8 @@ func ColorRangeName(r int) string {
 // Recognized names include "limited", "full", "tv", "pc", and similar standard
 // ranges. The lookup is case-insensitive.
 //
-// If the name is empty or unrecognized,an error is returned.
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName.
 func ColorRangeFromName(name string) (int, error) {
 	if name == "" {
 		return -1, ErrInvalidArgument
@@ -295,7 +285,8 @@ func ColorRangeFromName(name string) (int, error) {
 	defer C.free(unsafe.Pointer(cs))
 	ret := C.av_color_range_from_name(cs)
 	if ret < 0 {
-		return int(ret), fmt.Errorf("unknown color range: %s (err %d)", name, int(ret))
+		return int(ret), avErrorf(ErrUnknownColorName, int(ret),
+			"color range %q", name)
 	}
 	return int(ret), nil
 }
@@ -322,8 +313,9 @@ func ColorPrimariesName(p int) string {
 // Recognized names include standard sets like "BT.709", "BT.2020", and "SMPTE
 // 170M". The lookup is case-insensitive.
 //
-// If the input string is empty or unrecognized, an error is returned. A
-// successful call returns the integer identifier for the primaries.
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName. A successful call returns the integer identifier for
+// the primaries.
 func ColorPrimariesFromName(name string) (int, error) {
 	if name == "" {
 		return -1, ErrInvalidArgument
@@ -332,7 +324,8 @@ func ColorPrimariesFromName(name string) (int, error) {
 	defer C.free(unsafe.Pointer(cs))
 	ret := C.av_color_primaries_from_name(cs)
 	if ret < 0 {
-		return int(ret), fmt.Errorf("unknown color primaries: %s (err %d)", name, int(ret))
+		return int(ret), avErrorf(ErrUnknownColorName, int(ret),
+			"color primaries %q", name)
 	}
 	return int(ret), nil
 }
@@ -359,9 +352,9 @@ func ColorTransferName(t int) string {
 // Recognized names include standard transfer curves like "bt709", "smpte240m",
 // "arib-std-b67" (HLG), and "smpte2084" (PQ). The lookup is case-insensitive.
 //
-// If the input string is empty or unrecognized, an error is returned. A
-// successful call returns the integer identifier for the transfer
-// characteristic.
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName. A successful call returns the integer identifier for
+// the transfer characteristic.
 func ColorTransferFromName(name string) (int, error) {
 	if name == "" {
 		return -1, ErrInvalidArgument
@@ -370,7 +363,8 @@ func ColorTransferFromName(name string) (int, error) {
 	defer C.free(unsafe.Pointer(cs))
 	ret := C.av_color_transfer_from_name(cs)
 	if ret < 0 {
-		return int(ret), fmt.Errorf("unknown color transfer: %s (err %d)", name, int(ret))
+		return int(ret), avErrorf(ErrUnknownColorName, int(ret),
+			"color transfer %q", name)
 	}
 	return int(ret), nil
 }
@@ -394,8 +388,9 @@ func ColorSpaceName(s int) string {
 // Recognized names include standard color spaces like "BT.601", "BT.709", and
 // "BT.2020". The lookup is case-insensitive.
 //
-// If the input string is empty or unrecognized, an error is returned. A
-// successful call returns the integer identifier for the color space.
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName. A successful call returns the integer identifier for
+// the color space.
 func ColorSpaceFromName(name string) (int, error) {
 	if name == "" {
 		return -1, ErrInvalidArgument
@@ -404,7 +399,8 @@ func ColorSpaceFromName(name string) (int, error) {
 	defer C.free(unsafe.Pointer(cs))
 	ret := C.av_color_space_from_name(cs)
 	if ret < 0 {
-		return int(ret), fmt.Errorf("unknown color space: %s (err %d)", name, int(ret))
+		return int(ret), avErrorf(ErrUnknownColorName, int(ret),
+			"color space %q", name)
 	}
 	return int(ret), nil
 }
@@ -431,8 +427,9 @@ func ChromaLocationName(loc int) string {
 // Recognized names include standard locations such as "left", "center", and
 // "top-left". The lookup is case-insensitive.
 //
-// An empty input or unknown name produces an error. A successful call returns
-// the integer identifier for the chroma location.
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName. A successful call returns the integer identifier for
+// the chroma location.
 func ChromaLocationFromName(name string) (int, error) {
 	if name == "" {
 		return -1, ErrInvalidArgument
@@ -441,7 +438,8 @@ func ChromaLocationFromName(name string) (int, error) {
 	defer C.free(unsafe.Pointer(cs))
 	ret := C.av_chroma_location_from_name(cs)
 	if ret < 0 {
-		return int(ret), fmt.Errorf("unknown chroma location: %s (err %d)", name, int(ret))
+		return int(ret), avErrorf(ErrUnknownColorName, int(ret),
+			"chroma location %q", name)
 	}
 	return int(ret), nil
 }
@@ -451,13 +449,15 @@ func ChromaLocationFromName(name string) (int, error) {
 //
 // The returned xpos and ypos values indicate the horizontal and vertical
 // placement of chroma samples relative to the top-left corner of a luma
-// sample. If the enumeration is unrecognized, the function returns an error.
+// sample. If the enumeration is unrecognized, the function returns an error
+// wrapping ErrInvalidArgument and the libavutil AVError.
 func ChromaLocationEnumToPos(pos int) (xpos, ypos int, err error) {
 	var cx C.int
 	var cy C.int
 	ret := C.av_chroma_location_enum_to_pos(&cx, &cy, C.enum_AVChromaLocation(pos))
 	if ret < 0 {
-		return 0, 0, fmt.Errorf("av_chroma_location_enum_to_pos error: %d", int(ret))
+		return 0, 0, avErrorf(ErrInvalidArgument, int(ret),
+			"chroma location %d", pos)
 	}
 	return int(cx), int(cy), nil
 }
@@ -479,7 +479,8 @@ func ChromaLocationPosToEnum(xpos, ypos int) (int, error) {
 // Pixel formats define how pixel data is stored in memory, including bit depth
 // and component ordering (e.g., "yuv420p", "rgb24").
 //
-// An empty name returns ErrInvalidArgument. Unknown names produce a Go error.
+// An empty name returns ErrInvalidArgument and an unknown one returns
+// ErrUnknownPixelFormat.
 // On success, the function returns the corresponding PixelFormat value.
 func GetPixFmt(name string) (PixelFormat, error) {
 	if name == "" {
@@ -489,7 +490,7 @@ func GetPixFmt(name string) (PixelFormat, error) {
 	defer C.free(unsafe.Pointer(cs))
 	ret := C.av_get_pix_fmt(cs)
 	if ret == C.AV_PIX_FMT_NONE {
-		return PixelFormat(ret), fmt.Errorf("pixel format not found: %s", name)
+		return PixelFormat(ret), fmt.Errorf("%w: %q", ErrUnknownPixelFormat, name)
 	}
 	return PixelFormat(ret), nil
 }
@@ -538,18 +539,30 @@ func GetPixFmtString(pf PixelFormat) (string, error) {
 // for paletted formats.
 //
 // dstElementSize indicates the number of bytes per element in the destination
-// buffer.
+// buffer and must be 2 or 4.
 //
 // The function automatically handles planar and packed formats, converting
 // component offsets according to the pixel format descriptor. If desc is nil
-// or its internal C pointer is nil, ErrInvalidArgument is returned. Otherwise,
-// the function fills dst with the requested pixel data.
+// or its internal C pointer is nil, ErrInvalidArgument is returned. If the
+// line would reach outside of dst or of any plane in data, a *BoundsError
+// wrapping ErrOutOfBounds is returned and nothing is read. Otherwise, the
+// function fills dst with the requested pixel data.
 func ReadImageLine2(dst []byte, data [4][]byte, linesize [4]int,
 	desc *PixFmtDescRef, x, y, c, w int, readPalComponent bool,
 	dstElementSize int) error {
 	if desc == nil || desc.cptr == nil {
 		return ErrInvalidArgument
 	}
+	if dstElementSize != 2 && dstElementSize != 4 {
+		return ErrInvalidArgument
+	}
+	if err := checkLineBuffer(len(dst), w, dstElementSize); err != nil {
+		return err
+	}
+	err := checkImageLine(data, linesize, desc, x, y, c, w, readPalComponent)
+	if err != nil || w == 0 {
+		return err
+	}
 	var dstPtr unsafe.Pointer
 	if len(dst) > 0 {
 		dstPtr = unsafe.Pointer(&dst[0])
@@ -588,13 +601,22 @@ func ReadImageLine2(dst []byte, data [4][]byte, linesize [4]int,
 //
 // The function automatically handles planar and packed formats, converting
 // component offsets according to the pixel format descriptor. If desc is nil
-// or its internal C pointer is nil, ErrInvalidArgument is returned. On
-// success, dst will be filled with the requested pixel data.
+// or its internal C pointer is nil, ErrInvalidArgument is returned. If the
+// line would reach outside of dst or of any plane in data, a *BoundsError
+// wrapping ErrOutOfBounds is returned and nothing is read. On success, dst
+// will be filled with the requested pixel data.
 func ReadImageLine(dst []uint16, data [4][]byte, linesize [4]int,
 	desc *PixFmtDescRef, x, y, c, w int, readPalComponent bool) error {
 	if desc == nil || desc.cptr == nil {
 		return ErrInvalidArgument
 	}
+	if err := checkLineBuffer(len(dst), w, 1); err != nil {
+		return err
+	}
+	err := checkImageLine(data, linesize, desc, x, y, c, w, readPalComponent)
+	if err != nil || w == 0 {
+		return err
+	}
 	var dstPtr unsafe.Pointer
 	if len(dst) > 0 {
 		dstPtr = unsafe.Pointer(&dst[0])
@@ -627,16 +649,29 @@ func ReadImageLine(dst []uint16, data [4][]byte, linesize [4]int,
 //
 // x and y specify the starting pixel coordinates in the destination image, c
 // selects the component to write, and w is the number of pixels to copy.
-// srcElementSize indicates the size in bytes of each element in src.
+// srcElementSize indicates the size in bytes of each element in src and must
+// be 2 or 4.
 //
 // The function automatically handles planar and packed formats, writing each
 // component according to the pixel format descriptor. If desc is nil or its
-// internal C pointer is nil, ErrInvalidArgument is returned.
+// internal C pointer is nil, ErrInvalidArgument is returned. If the line would
+// reach outside of src or of any plane in data, a *BoundsError wrapping
+// ErrOutOfBounds is returned and nothing is written.
 func WriteImageLine2(src []byte, data [4][]byte, linesize [4]int,
 	desc *PixFmtDescRef, x, y, c, w int, srcElementSize int) error {
 	if desc == nil || desc.cptr == nil {
 		return ErrInvalidArgument
 	}
+	if srcElementSize != 2 && srcElementSize != 4 {
+		return ErrInvalidArgument
+	}
+	if err := checkLineBuffer(len(src), w, srcElementSize); err != nil {
+		return err
+	}
+	err := checkImageLine(data, linesize, desc, x, y, c, w, false)
+	if err != nil || w == 0 {
+		return err
+	}
 	var srcPtr unsafe.Pointer
 	if len(src) > 0 {
 		srcPtr = unsafe.Pointer(&src[0])
@@ -665,13 +700,22 @@ func WriteImageLine2(src []byte, data [4][]byte, linesize [4]int,
 // selects the component to write, and w is the number of pixels to copy.
 //
 // The function automatically handles planar and packed formats. If desc is
-// nil or its internal C pointer is nil, ErrInvalidArgument is returned. On
-// success, the specified pixel data from src is written into the target image.
+// nil or its internal C pointer is nil, ErrInvalidArgument is returned. If the
+// line would reach outside of src or of any plane in data, a *BoundsError
+// wrapping ErrOutOfBounds is returned and nothing is written. On success, the
+// specified pixel data from src is written into the target image.
 func WriteImageLine(src []uint16, data [4][]byte, linesize [4]int,
 	desc *PixFmtDescRef, x, y, c, w int) error {
 	if desc == nil || desc.cptr == nil {
 		return ErrInvalidArgument
 	}
+	if err := checkLineBuffer(len(src), w, 1); err != nil {
+		return err
+	}
+	err := checkImageLine(data, linesize, desc, x, y, c, w, false)
+	if err != nil || w == 0 {
+		return err
+	}
 	var srcPtr unsafe.Pointer
 	if len(src) > 0 {
 		srcPtr = unsafe.Pointer(&src[0])
@@ -694,38 +738,30 @@ func WriteImageLine(src []uint16, data [4][]byte, linesize [4]int,
 //
 // Some pixel formats have different memory layouts depending on the system’s
 // endianness. This function returns the equivalent format with the opposite
-// endianness. If no swapped-endianness version exists, an error is returned.
+// endianness. If no swapped-endianness version exists, ErrNoEndianSwap is
+// returned.
 func PixFmtSwapEndianness(pf PixelFormat) (PixelFormat, error) {
 	ret := C.av_pix_fmt_swap_endianness(cPixelFormat(pf))
 	if ret == C.AV_PIX_FMT_NONE {
-		return PixelFormat(ret), fmt.Errorf("no swapped-endianness pixel format for: %d", int(pf))
+		return PixelFormat(ret), fmt.Errorf("%w: %d", ErrNoEndianSwap, int(pf))
 	}
 	return PixelFormat(ret), nil
 }
 
 // ---------------- Loss & best-format selection ----------------
 
-const (
-	FF_LOSS_RESOLUTION        = 0x0001
-	FF_LOSS_DEPTH             = 0x0002
-	FF_LOSS_COLORSPACE        = 0x0004
-	FF_LOSS_ALPHA             = 0x0008
-	FF_LOSS_COLORQUANT        = 0x0010
-	FF_LOSS_CHROMA            = 0x0020
-	FF_LOSS_EXCESS_RESOLUTION = 0x0040
-	FF_LOSS_EXCESS_DEPTH      = 0x0080
-)
-
-// GetPixFmtLoss returns an integer representing the information loss when
-// converting from the source pixel format to the destination pixel format.
+// GetPixFmtLoss returns the information lost when converting from the
+// source pixel format to the destination pixel format.
 //
 // dst and src are the destination and source PixelFormat values, respectively.
 // hasAlpha indicates whether the alpha channel should be considered in the
 // comparison.
 //
-// The returned value is a non-negative integer where 0 indicates no loss and
-// higher values indicate greater loss of color precision or components.
-func GetPixFmtLoss(dst, src PixelFormat, hasAlpha bool) (int, error) {
+// The returned Loss is a set of FF_LOSS_* bits, 0 meaning no loss. Unknown
+// formats return ErrUnknownPixelFormat, and hardware accelerated formats or
+// formats without components, which libavutil cannot compare, return
+// ErrInvalidArgument.
+func GetPixFmtLoss(dst, src PixelFormat, hasAlpha bool) (Loss, error) {
 	var ha C.int
 	if hasAlpha {
 		ha = 1
@@ -733,7 +769,10 @@ func GetPixFmtLoss(dst, src PixelFormat, hasAlpha bool) (int, error) {
 		ha = 0
 	}
 	ret := C.av_get_pix_fmt_loss(cPixelFormat(dst), cPixelFormat(src), ha)
-	return int(ret), nil
+	if ret < 0 {
+		return 0, lossError(int(ret))
+	}
+	return Loss(ret), nil
 }
 
 // FindBestPixFmtOf2 returns the best pixel format from two candidates for
@@ -743,10 +782,11 @@ func GetPixFmtLoss(dst, src PixelFormat, hasAlpha bool) (int, error) {
 // PixelFormat. hasAlpha indicates whether the alpha channel should be
 // considered.
 //
-// The function returns the chosen PixelFormat, an integer loss value, and an
-// error if no suitable format could be found. The loss value quantifies the
-// difference between the source and selected destination format.
-func FindBestPixFmtOf2(dst1, dst2, src PixelFormat, hasAlpha bool) (best PixelFormat, loss int, err error) {
+// The function returns the chosen PixelFormat, its FF_LOSS_* bits, and
+// ErrNoSuitableFormat if no suitable format could be found. The loss value
+// quantifies the difference between the source and selected destination
+// format.
+func FindBestPixFmtOf2(dst1, dst2, src PixelFormat, hasAlpha bool) (best PixelFormat, loss Loss, err error) {
 	var lossC C.int
 	var ha C.int
 	if hasAlpha {
@@ -754,9 +794,9 @@ func FindBestPixFmtOf2(dst1, dst2, src PixelFormat, hasAlpha bool) (best PixelFo
 	}
 	ret := C.av_find_best_pix_fmt_of_2(cPixelFormat(dst1), cPixelFormat(dst2), cPixelFormat(src), ha, &lossC)
 	if ret == C.AV_PIX_FMT_NONE {
-		return PixelFormat(ret), int(lossC), fmt.Errorf("no suitable pixel format found")
+		return PixelFormat(ret), Loss(lossC), ErrNoSuitableFormat
 	}
-	return PixelFormat(ret), int(lossC), nil
+	return PixelFormat(ret), Loss(lossC), nil
 }
 
 // Helpers
@@ -766,6 +806,13 @@ func cPixelFormat(p PixelFormat) C.enum_AVPixelFormat {
 	return C.enum_AVPixelFormat(p)
 }
 
+// helper: describe a libavutil error code with av_strerror
+func avStrError(code int) string {
+	var buf [C.AV_ERROR_MAX_STRING_SIZE]C.char
+	C.av_strerror(C.int(code), &buf[0], C.size_t(len(buf)))
+	return C.GoString(&buf[0])
+}
+
 // helper: prepare C arrays for data[4] and linesize[4]
 func buildPlanePointers(data [4][]byte, linesize [4]int) (cdata [4]*C.uint8_t, clinesize [4]C.int) {
 	for i := 0; i < 4; i++ {
@@ -0,0 +1,448 @@
+package gopixfmts
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+//go:generate go run gen_consts.go
+//go:generate go run gen_table.go
+
+// This file holds pure-Go ports of the libavutil pixdesc.c routines, driven by
+// the generated pixFmtDescriptors table. They back the cgo-free build and are
+// checked against libavutil by the cgo tests.
+
+// libavutil error codes returned by the ported routines.
+const (
+	averrorEINVAL = -22
+	averrorENOSYS = -38
+)
+
+// pixFmtDescriptor is the pure-Go mirror of AVPixFmtDescriptor.
+type pixFmtDescriptor struct {
+	name         string
+	alias        string
+	nbComponents int
+	log2ChromaW  int
+	log2ChromaH  int
+	flags        PixFmtFlag
+	comp         [4]ComponentDescriptor
+}
+
+// nativeBigEndian reports whether the host stores integers big-endian.
+var nativeBigEndian = binary.NativeEndian.Uint16([]byte{0, 1}) == 1
+
+// helper: pick the native endian variant of a name, like X_NE in libavutil
+func nativeEndian(be, le string) string {
+	if nativeBigEndian {
+		return be
+	}
+	return le
+}
+
+// goPixFmtDescGet mirrors av_pix_fmt_desc_get.
+func goPixFmtDescGet(pf PixelFormat) *pixFmtDescriptor {
+	if pf < 0 || int(pf) >= len(pixFmtDescriptors) {
+		return nil
+	}
+	return &pixFmtDescriptors[pf]
+}
+
+// goPixFmtDescNext mirrors av_pix_fmt_desc_next, walking descriptors by
+// PixelFormat. PixFmtNone starts the iteration.
+func goPixFmtDescNext(prev PixelFormat) PixelFormat {
+	for pf := prev + 1; int(pf) < len(pixFmtDescriptors); pf++ {
+		if pixFmtDescriptors[pf].name != "" {
+			return pf
+		}
+	}
+	return PixFmtNone
+}
+
+// goGetPixFmtInternal looks a name up among descriptor names and aliases.
+func goGetPixFmtInternal(name string) PixelFormat {
+	for pf, d := range pixFmtDescriptors {
+		if d.name != "" && (name == d.name || matchName(name, d.alias)) {
+			return PixelFormat(pf)
+		}
+	}
+	return PixFmtNone
+}
+
+// goGetPixFmt mirrors av_get_pix_fmt, including the rgb32/bgr32 shorthands
+// and the fallback to the native endian variant of the name.
+func goGetPixFmt(name string) PixelFormat {
+	switch name {
+	case "rgb32":
+		name = nativeEndian("argb", "bgra")
+	case "bgr32":
+		name = nativeEndian("abgr", "rgba")
+	}
+	pf := goGetPixFmtInternal(name)
+	if pf == PixFmtNone {
+		pf = goGetPixFmtInternal(name + nativeEndian("be", "le"))
+	}
+	return pf
+}
+
+// matchName mirrors av_match_name: names is a comma separated list matched
+// case-insensitively, entries prefixed with '-' negate and "ALL" matches
+// everything.
+func matchName(name, names string) bool {
+	if names == "" {
+		return false
+	}
+	for _, entry := range strings.Split(names, ",") {
+		negate := strings.HasPrefix(entry, "-")
+		entry = strings.TrimPrefix(entry, "-")
+		if strings.EqualFold(name, entry) || entry == "ALL" {
+			return !negate
+		}
+	}
+	return false
+}
+
+// goGetPixFmtName mirrors av_get_pix_fmt_name.
+func goGetPixFmtName(pf PixelFormat) string {
+	if d := goPixFmtDescGet(pf); d != nil {
+		return d.name
+	}
+	return ""
+}
+
+// goGetPixFmtString mirrors av_get_pix_fmt_string.
+func goGetPixFmtString(pf PixelFormat) string {
+	if pf < 0 {
+		return "name nb_components nb_bits"
+	}
+	d := goPixFmtDescGet(pf)
+	if d == nil {
+		return ""
+	}
+	return fmt.Sprintf("%-11s %7d %10d", d.name, d.nbComponents,
+		goGetBitsPerPixel(d))
+}
+
+// goGetBitsPerPixel mirrors av_get_bits_per_pixel.
+func goGetBitsPerPixel(d *pixFmtDescriptor) int {
+	bits := 0
+	log2Pixels := d.log2ChromaW + d.log2ChromaH
+	for c := 0; c < d.nbComponents; c++ {
+		s := log2Pixels
+		if c == 1 || c == 2 {
+			s = 0
+		}
+		bits += d.comp[c].Depth << s
+	}
+	return bits >> log2Pixels
+}
+
+// goGetPaddedBitsPerPixel mirrors av_get_padded_bits_per_pixel.
+func goGetPaddedBitsPerPixel(d *pixFmtDescriptor) int {
+	var steps [4]int
+	log2Pixels := d.log2ChromaW + d.log2ChromaH
+	for c := 0; c < d.nbComponents; c++ {
+		s := log2Pixels
+		if c == 1 || c == 2 {
+			s = 0
+		}
+		steps[d.comp[c].Plane] = d.comp[c].Step << s
+	}
+	bits := steps[0] + steps[1] + steps[2] + steps[3]
+	if d.flags&PixFmtFlagBitstream == 0 {
+		bits *= 8
+	}
+	return bits >> log2Pixels
+}
+
+// goPixFmtCountPlanes mirrors av_pix_fmt_count_planes.
+func goPixFmtCountPlanes(pf PixelFormat) int {
+	d := goPixFmtDescGet(pf)
+	if d == nil {
+		return averrorEINVAL
+	}
+	var planes [4]bool
+	for i := 0; i < d.nbComponents; i++ {
+		planes[d.comp[i].Plane] = true
+	}
+	n := 0
+	for _, p := range planes {
+		if p {
+			n++
+		}
+	}
+	return n
+}
+
+// goPixFmtSwapEndianness mirrors av_pix_fmt_swap_endianness.
+func goPixFmtSwapEndianness(pf PixelFormat) PixelFormat {
+	d := goPixFmtDescGet(pf)
+	if d == nil || len(d.name) < 2 {
+		return PixFmtNone
+	}
+	stem, suffix := d.name[:len(d.name)-2], d.name[len(d.name)-2:]
+	switch suffix {
+	case "be":
+		return goGetPixFmtInternal(stem + "le")
+	case "le":
+		return goGetPixFmtInternal(stem + "be")
+	}
+	return PixFmtNone
+}
+
+// goColorName looks a value up in one of the generated color name tables.
+func goColorName(names []string, v int) string {
+	if v < 0 || v >= len(names) {
+		return ""
+	}
+	return names[v]
+}
+
+// goColorFromName mirrors the av_color_*_from_name family, which accept any
+// name that starts with a table entry.
+func goColorFromName(names []string, name string) int {
+	for i, n := range names {
+		if n != "" && strings.HasPrefix(name, n) {
+			return i
+		}
+	}
+	return averrorEINVAL
+}
+
+// goChromaLocationEnumToPos mirrors av_chroma_location_enum_to_pos.
+func goChromaLocationEnumToPos(loc int) (xpos, ypos, ret int) {
+	if loc <= int(ChromaLocationUnspecified) || loc >= int(ChromaLocationNB) {
+		return 0, 0, averrorEINVAL
+	}
+	loc--
+	xpos = (loc & 1) * 128
+	below := 0
+	if loc < 4 {
+		below = 1
+	}
+	ypos = ((loc >> 1) ^ below) * 128
+	return xpos, ypos, 0
+}
+
+// goChromaLocationPosToEnum mirrors av_chroma_location_pos_to_enum.
+func goChromaLocationPosToEnum(xpos, ypos int) int {
+	for loc := int(ChromaLocationUnspecified) + 1; loc < int(ChromaLocationNB); loc++ {
+		x, y, ret := goChromaLocationEnumToPos(loc)
+		if ret == 0 && x == xpos && y == ypos {
+			return loc
+		}
+	}
+	return int(ChromaLocationUnspecified)
+}
+
+// Color families used by the loss computation.
+const (
+	colorNA = iota - 1
+	colorRGB
+	colorGray
+	colorYUV
+	colorYUVJPEG
+	colorXYZ
+)
+
+// colorType mirrors get_color_type in pixdesc.c.
+func colorType(d *pixFmtDescriptor) int {
+	switch {
+	case d.flags&PixFmtFlagPAL != 0:
+		return colorRGB
+	case d.nbComponents == 1 || d.nbComponents == 2:
+		return colorGray
+	case strings.HasPrefix(d.name, "yuvj"):
+		return colorYUVJPEG
+	case d.flags&PixFmtFlagRGB != 0:
+		return colorRGB
+	case d.flags&PixFmtFlagXYZ != 0:
+		return colorXYZ
+	case d.nbComponents == 0:
+		return colorNA
+	}
+	return colorYUV
+}
+
+// goPixFmtScore mirrors get_pix_fmt_score in pixdesc.c. It returns a score
+// where higher is better together with the FF_LOSS_* bits incurred when
+// converting src to dst, only considering the loss bits set in consider.
+// Negative scores signal invalid or hardware formats.
+func goPixFmtScore(dst, src PixelFormat, consider int) (score, loss int) {
+	srcDesc, dstDesc := goPixFmtDescGet(src), goPixFmtDescGet(dst)
+	if srcDesc == nil || dstDesc == nil {
+		return -4, 0
+	}
+	if srcDesc.flags&PixFmtFlagHWAccel != 0 ||
+		dstDesc.flags&PixFmtFlagHWAccel != 0 {
+		if dst == src {
+			return -1, 0
+		}
+		return -2, 0
+	}
+	if dst == src {
+		return math.MaxInt32, 0
+	}
+	if srcDesc.nbComponents == 0 || dstDesc.nbComponents == 0 {
+		return -3, 0
+	}
+
+	score = math.MaxInt32 - 1
+	srcColor, dstColor := colorType(srcDesc), colorType(dstDesc)
+	nbComponents := min(srcDesc.nbComponents, dstDesc.nbComponents)
+	if dst == PixFmtPal8 {
+		nbComponents = min(srcDesc.nbComponents, 4)
+	}
+
+	for i := 0; i < nbComponents; i++ {
+		depthMinus1 := dstDesc.comp[i].Depth - 1
+		if dst == PixFmtPal8 {
+			depthMinus1 = 7 / nbComponents
+		}
+		delta := srcDesc.comp[i].Depth - 1 - depthMinus1
+		if delta > 0 && consider&FF_LOSS_DEPTH != 0 {
+			loss |= FF_LOSS_DEPTH
+			score -= 65536 >> depthMinus1
+		} else if delta < 0 && consider&FF_LOSS_EXCESS_DEPTH != 0 {
+			// Favour formats with the smallest depth difference.
+			loss |= FF_LOSS_EXCESS_DEPTH
+			score += delta
+		}
+	}
+
+	if consider&FF_LOSS_RESOLUTION != 0 {
+		if dstDesc.log2ChromaW > srcDesc.log2ChromaW {
+			loss |= FF_LOSS_RESOLUTION
+			score -= 256 << dstDesc.log2ChromaW
+		}
+		if dstDesc.log2ChromaH > srcDesc.log2ChromaH {
+			loss |= FF_LOSS_RESOLUTION
+			score -= 256 << dstDesc.log2ChromaH
+		}
+		// Don't favour 4:2:2 over 4:2:0 when downsampling 4:4:4, since
+		// 4:2:0 is much better supported by decoders.
+		if dstDesc.log2ChromaW == 1 && srcDesc.log2ChromaW == 0 &&
+			dstDesc.log2ChromaH == 1 && srcDesc.log2ChromaH == 0 {
+			score += 512
+		}
+	}
+
+	if consider&FF_LOSS_EXCESS_RESOLUTION != 0 {
+		// Favour formats where chroma does not need to be upsampled.
+		if dstDesc.log2ChromaW < srcDesc.log2ChromaW {
+			loss |= FF_LOSS_EXCESS_RESOLUTION
+			score -= 1 << (srcDesc.log2ChromaW - dstDesc.log2ChromaW)
+		}
+		if dstDesc.log2ChromaH < srcDesc.log2ChromaH {
+			loss |= FF_LOSS_EXCESS_RESOLUTION
+			score -= 1 << (srcDesc.log2ChromaH - dstDesc.log2ChromaH)
+		}
+	}
+
+	if consider&FF_LOSS_COLORSPACE != 0 {
+		switch dstColor {
+		case colorRGB:
+			if srcColor != colorRGB && srcColor != colorGray {
+				loss |= FF_LOSS_COLORSPACE
+			}
+		case colorGray:
+			if srcColor != colorGray {
+				loss |= FF_LOSS_COLORSPACE
+			}
+		case colorYUV:
+			if srcColor != colorYUV {
+				loss |= FF_LOSS_COLORSPACE
+			}
+		case colorYUVJPEG:
+			if srcColor != colorYUVJPEG && srcColor != colorYUV &&
+				srcColor != colorGray {
+				loss |= FF_LOSS_COLORSPACE
+			}
+		default:
+			if srcColor != dstColor {
+				loss |= FF_LOSS_COLORSPACE
+			}
+		}
+	}
+	if loss&FF_LOSS_COLORSPACE != 0 {
+		score -= (nbComponents * 65536) >>
+			min(dstDesc.comp[0].Depth-1, srcDesc.comp[0].Depth-1)
+	}
+
+	if dstColor == colorGray && srcColor != colorGray &&
+		consider&FF_LOSS_CHROMA != 0 {
+		loss |= FF_LOSS_CHROMA
+		score -= 2 * 65536
+	}
+	srcAlpha := srcDesc.flags&PixFmtFlagAlpha != 0
+	if dstDesc.flags&PixFmtFlagAlpha == 0 && srcAlpha &&
+		consider&FF_LOSS_ALPHA != 0 {
+		loss |= FF_LOSS_ALPHA
+		score -= 65536
+	}
+	if dst == PixFmtPal8 && consider&FF_LOSS_COLORQUANT != 0 &&
+		src != PixFmtPal8 && (srcColor != colorGray ||
+		(srcAlpha && consider&FF_LOSS_ALPHA != 0)) {
+		loss |= FF_LOSS_COLORQUANT
+		score -= 65536
+	}
+	return score, loss
+}
+
+// lossMask returns the FF_LOSS_* bits to consider for the given alpha
+// handling.
+func lossMask(hasAlpha bool) int {
+	if hasAlpha {
+		return ^0
+	}
+	return ^FF_LOSS_ALPHA
+}
+
+// goGetPixFmtLoss mirrors av_get_pix_fmt_loss. Negative results are the
+// error scores of goPixFmtScore.
+func goGetPixFmtLoss(dst, src PixelFormat, hasAlpha bool) int {
+	score, loss := goPixFmtScore(dst, src, lossMask(hasAlpha))
+	if score < 0 {
+		return score
+	}
+	return loss
+}
+
+// goFindBestPixFmtOf2 mirrors av_find_best_pix_fmt_of_2 with no loss mask
+// supplied by the caller.
+func goFindBestPixFmtOf2(dst1, dst2, src PixelFormat,
+	hasAlpha bool) (PixelFormat, int) {
+	desc1, desc2 := goPixFmtDescGet(dst1), goPixFmtDescGet(dst2)
+
+	var best PixelFormat
+	switch {
+	case desc1 == nil:
+		best = dst2
+	case desc2 == nil:
+		best = dst1
+	default:
+		mask := lossMask(hasAlpha)
+		score1, _ := goPixFmtScore(dst1, src, mask)
+		score2, _ := goPixFmtScore(dst2, src, mask)
+		switch {
+		case score1 != score2:
+			best = dst1
+			if score1 < score2 {
+				best = dst2
+			}
+		case goGetPaddedBitsPerPixel(desc1) != goGetPaddedBitsPerPixel(desc2):
+			best = dst1
+			if goGetPaddedBitsPerPixel(desc2) < goGetPaddedBitsPerPixel(desc1) {
+				best = dst2
+			}
+		default:
+			best = dst1
+			if desc2.nbComponents < desc1.nbComponents {
+				best = dst2
+			}
+		}
+	}
+	return best, goGetPixFmtLoss(best, src, hasAlpha)
+}
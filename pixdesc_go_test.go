@@ -0,0 +1,163 @@
+//go:build cgo
+
+package gopixfmts
+
+import (
+	"strings"
+	"testing"
+)
+
+// These tests check the generated table and the pure-Go ports used by the
+// cgo-free build against libavutil. A failure usually means the table needs
+// to be regenerated with go generate.
+
+func Test_PixFmtDescriptors_MatchLibavutil(t *testing.T) {
+	if len(pixFmtDescriptors) != int(PixFmtNB) {
+		t.Fatalf("table has %d entries, libavutil has %d",
+			len(pixFmtDescriptors), PixFmtNB)
+	}
+	for pf := PixelFormat(0); pf < PixFmtNB; pf++ {
+		desc, err := PixFmtDescGet(pf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := goPixFmtDescGet(pf)
+		want := pixFmtDescriptor{
+			name:         desc.Name(),
+			alias:        desc.Alias(),
+			nbComponents: desc.NbComponents(),
+			log2ChromaW:  desc.Log2ChromaW(),
+			log2ChromaH:  desc.Log2ChromaH(),
+			flags:        desc.Flags(),
+		}
+		for i := range want.comp {
+			want.comp[i], _ = desc.Component(i)
+		}
+		if *got != want {
+			t.Fatalf("%s: table has %+v, libavutil has %+v", want.name,
+				*got, want)
+		}
+
+		bits, _ := GetBitsPerPixel(desc)
+		padded, _ := GetPaddedBitsPerPixel(desc)
+		if goGetBitsPerPixel(got) != bits ||
+			goGetPaddedBitsPerPixel(got) != padded {
+			t.Fatalf("%s: bits per pixel mismatch", want.name)
+		}
+
+		planes, err := PixFmtCountPlanes(pf)
+		if err != nil || goPixFmtCountPlanes(pf) != planes {
+			t.Fatalf("%s: plane count mismatch", want.name)
+		}
+
+		swapped, _ := PixFmtSwapEndianness(pf)
+		if goPixFmtSwapEndianness(pf) != swapped {
+			t.Fatalf("%s: swapped endianness mismatch", want.name)
+		}
+
+		str, _ := GetPixFmtString(pf)
+		if goGetPixFmtString(pf) != str {
+			t.Fatalf("%s: got %q, want %q", want.name,
+				goGetPixFmtString(pf), str)
+		}
+
+		names := []string{want.name, strings.ToUpper(want.name)}
+		if want.alias != "" {
+			names = append(names, strings.Split(want.alias, ",")...)
+		}
+		for _, name := range names {
+			id, _ := GetPixFmt(name)
+			if goGetPixFmt(name) != id {
+				t.Fatalf("%q: got %d, want %d", name, goGetPixFmt(name), id)
+			}
+		}
+	}
+
+	for _, name := range []string{"rgb32", "bgr32", "yuv420p10", "gray16",
+		"bogus", "yuv"} {
+		id, _ := GetPixFmt(name)
+		if goGetPixFmt(name) != id {
+			t.Fatalf("%q: got %d, want %d", name, goGetPixFmt(name), id)
+		}
+	}
+}
+
+func Test_ColorNames_MatchLibavutil(t *testing.T) {
+	tables := []struct {
+		names    []string
+		name     func(int) string
+		fromName func(string) (int, error)
+	}{
+		{colorRangeNames[:], ColorRangeName, ColorRangeFromName},
+		{colorPrimariesNames[:], ColorPrimariesName, ColorPrimariesFromName},
+		{colorTransferNames[:], ColorTransferName, ColorTransferFromName},
+		{colorSpaceNames[:], ColorSpaceName, ColorSpaceFromName},
+		{chromaLocationNames[:], ChromaLocationName, ChromaLocationFromName},
+	}
+	for _, tab := range tables {
+		for v := -1; v < len(tab.names)+2; v++ {
+			name := tab.name(v)
+			if got := goColorName(tab.names, v); got != name {
+				t.Fatalf("value %d: got %q, want %q", v, got, name)
+			}
+			if name == "" {
+				continue
+			}
+			for _, n := range []string{name, name + "x", "x" + name} {
+				want, _ := tab.fromName(n)
+				if got := goColorFromName(tab.names, n); got != want {
+					t.Fatalf("%q: got %d, want %d", n, got, want)
+				}
+			}
+		}
+	}
+
+	for loc := -1; loc <= int(ChromaLocationNB); loc++ {
+		x, y, err := ChromaLocationEnumToPos(loc)
+		gx, gy, ret := goChromaLocationEnumToPos(loc)
+		if (err != nil) != (ret < 0) || gx != x || gy != y {
+			t.Fatalf("location %d: got (%d, %d, %d), want (%d, %d, %v)",
+				loc, gx, gy, ret, x, y, err)
+		}
+	}
+	for _, x := range []int{0, 64, 128, 256} {
+		for _, y := range []int{0, 64, 128, 256} {
+			want, _ := ChromaLocationPosToEnum(x, y)
+			if got := goChromaLocationPosToEnum(x, y); got != want {
+				t.Fatalf("(%d, %d): got %d, want %d", x, y, got, want)
+			}
+		}
+	}
+}
+
+func Test_PixFmtLoss_MatchLibavutil(t *testing.T) {
+	for src := PixelFormat(0); src < PixFmtNB; src++ {
+		for dst := PixelFormat(0); dst < PixFmtNB; dst++ {
+			for _, alpha := range []bool{false, true} {
+				want, err := GetPixFmtLoss(dst, src, alpha)
+				got := goGetPixFmtLoss(dst, src, alpha)
+				if (err != nil) != (got < 0) || err == nil && Loss(got) != want {
+					t.Fatalf("%s -> %s (alpha %v): got %#x, want %#x",
+						GetPixFmtName(src), GetPixFmtName(dst), alpha, got,
+						want)
+				}
+			}
+		}
+	}
+
+	candidates := []PixelFormat{PixFmtYUV420P, PixFmtYUV444P, PixFmtNV12,
+		PixFmtRGB24, PixFmtRGBA, PixFmtGray8, PixFmtPal8, PixFmtYUV420P10LE,
+		PixFmtP010LE, PixFmtGBRP, PixFmtYA8}
+	for _, src := range candidates {
+		for _, dst1 := range candidates {
+			for _, dst2 := range candidates {
+				best, loss, _ := FindBestPixFmtOf2(dst1, dst2, src, true)
+				gotBest, gotLoss := goFindBestPixFmtOf2(dst1, dst2, src, true)
+				if gotBest != best || Loss(gotLoss) != loss {
+					t.Fatalf("%d, %d from %d: got (%d, %#x), want (%d, %#x)",
+						dst1, dst2, src, gotBest, gotLoss, best, loss)
+				}
+			}
+		}
+	}
+}
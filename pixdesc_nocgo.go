@@ -0,0 +1,573 @@
+//go:build !cgo
+
+package gopixfmts
+
+import "fmt"
+
+// PixFmtDescRef is a Descriptor that unambiguously describes how the bits of a
+// pixel are stored in the up to 4 data planes of an image. It also stores the
+// subsampling factors and number of components.
+//
+// Without cgo it refers to an entry of the generated descriptor table.
+type PixFmtDescRef struct {
+	desc *pixFmtDescriptor
+	id   PixelFormat
+}
+
+// ---------------- Descriptor acquisition ----------------
+
+// PixFmtDescGet returns a description of the given pixel format.
+//
+// The returned PixFmtDescRef provides information about the format’s
+// structure, including chroma subsampling, component count, and pixel layout.
+//
+// If the pixel format is unknown, the function returns ErrUnknownPixelFormat.
+// The returned PixFmtDescRef is read-only and managed internally; it does not
+// require manual cleanup.
+func PixFmtDescGet(pf PixelFormat) (*PixFmtDescRef, error) {
+	desc := goPixFmtDescGet(pf)
+	if desc == nil {
+		return nil, ErrUnknownPixelFormat
+	}
+	return &PixFmtDescRef{desc: desc, id: pf}, nil
+}
+
+// PixFmtDescNext returns the next pixel format descriptor.
+//
+// Calling this on a nil receiver returns the first descriptor. Calling it on
+// an existing descriptor returns the one that follows it.
+//
+// If there are no more descriptors, it returns nil. The returned descriptor
+// is read-only and does not require manual cleanup.
+func (prev *PixFmtDescRef) PixFmtDescNext() *PixFmtDescRef {
+	from := PixFmtNone
+	if prev != nil {
+		from = prev.id
+	}
+	next := goPixFmtDescNext(from)
+	if next == PixFmtNone {
+		return nil
+	}
+	return &PixFmtDescRef{desc: &pixFmtDescriptors[next], id: next}
+}
+
+// PixFmtDescID returns the pixel format identifier described by this
+// descriptor.
+//
+// If the descriptor is nil or invalid, it returns ErrInvalidArgument. If the
+// descriptor corresponds to an unknown format, it returns
+// ErrUnknownPixelFormat.
+//
+// A successful call yields the PixelFormat value that this descriptor
+// represents.
+func (desc *PixFmtDescRef) PixFmtDescID() (PixelFormat, error) {
+	if desc == nil || desc.desc == nil {
+		return PixFmtNone, ErrInvalidArgument
+	}
+	return desc.id, nil
+}
+
+// Name returns the symbolic name of this pixel format.
+//
+// The name is a short identifier such as "yuv420p" or "rgba". If the
+// descriptor is nil or does not provide a name, an empty string is returned.
+func (r *PixFmtDescRef) Name() string {
+	if r == nil || r.desc == nil {
+		return ""
+	}
+	return r.desc.name
+}
+
+// NbComponents returns the number of color components in this pixel format.
+//
+// For example, RGB formats have three components, RGBA formats have four,
+// and many YUV formats have three. If the descriptor is nil, zero is returned.
+func (r *PixFmtDescRef) NbComponents() int {
+	if r == nil || r.desc == nil {
+		return 0
+	}
+	return r.desc.nbComponents
+}
+
+// Log2ChromaW returns the horizontal chroma subsampling factor in base-2.
+//
+// Many YUV formats reduce chroma resolution horizontally. For instance,
+// 4:2:0 formats have a factor of 1 (corresponding to a halving of horizontal
+// chroma resolution). Formats without horizontal subsampling report 0.
+// A nil descriptor yields 0.
+func (r *PixFmtDescRef) Log2ChromaW() int {
+	if r == nil || r.desc == nil {
+		return 0
+	}
+	return r.desc.log2ChromaW
+}
+
+// Log2ChromaH returns the vertical chroma subsampling factor in base-2.
+//
+// Similar to Log2ChromaW, many YUV formats reduce vertical chroma resolution.
+// For example, a 4:2:0 format reports 1 (halved vertical chroma). A nil
+// descriptor yields 0.
+func (r *PixFmtDescRef) Log2ChromaH() int {
+	if r == nil || r.desc == nil {
+		return 0
+	}
+	return r.desc.log2ChromaH
+}
+
+// Flags returns a bitmask describing the properties of this pixel format.
+//
+// The flags indicate characteristics such as whether the format contains an
+// alpha channel, is planar or packed, or other structural traits. A nil
+// descriptor returns 0, meaning no flags are set.
+func (r *PixFmtDescRef) Flags() PixFmtFlag {
+	if r == nil || r.desc == nil {
+		return 0
+	}
+	return r.desc.flags
+}
+
+// Component returns the descriptor for the i-th color component of this pixel
+// format.
+//
+// The descriptor provides information about the component’s plane, step size,
+// bit offset, shift, and bit depth. The index i must be between 0 and 3.
+// If the index is out of range, or if the descriptor is nil,
+// ErrInvalidArgument is returned.
+func (r *PixFmtDescRef) Component(i int) (ComponentDescriptor, error) {
+	var zero ComponentDescriptor
+	if r == nil || r.desc == nil {
+		return zero, ErrInvalidArgument
+	}
+	if i < 0 || i >= 4 {
+		return zero, fmt.Errorf("%w: component index out of range: %d",
+			ErrInvalidArgument, i)
+	}
+	return r.desc.comp[i], nil
+}
+
+// Alias returns an alternate symbolic name for this pixel format, if one
+// exists.
+//
+// Some pixel formats have historical or shorthand names. If an alternate name
+// exists, it is returned as a Go string. If no alias exists or the descriptor
+// is nil, an empty string is returned.
+func (r *PixFmtDescRef) Alias() string {
+	if r == nil || r.desc == nil {
+		return ""
+	}
+	return r.desc.alias
+}
+
+// GetBitsPerPixel returns the number of meaningful bits per pixel for this
+// format.
+//
+// This counts all components in the format. It may differ from the actual
+// storage size due to padding or alignment. If the descriptor is nil,
+// ErrInvalidArgument is returned.
+func GetBitsPerPixel(r *PixFmtDescRef) (int, error) {
+	if r == nil || r.desc == nil {
+		return 0, ErrInvalidArgument
+	}
+	return goGetBitsPerPixel(r.desc), nil
+}
+
+// GetPaddedBitsPerPixel returns the number of bits per pixel including
+// padding.
+//
+// This accounts for alignment and packing rules in the format. The value is
+// generally larger than the raw bits-per-pixel count. If the descriptor is
+// nil, ErrInvalidArgument is returned.
+func GetPaddedBitsPerPixel(r *PixFmtDescRef) (int, error) {
+	if r == nil || r.desc == nil {
+		return 0, ErrInvalidArgument
+	}
+	return goGetPaddedBitsPerPixel(r.desc), nil
+}
+
+// PixFmtGetChromaSubSample returns the horizontal and vertical chroma shift
+// factors for the given pixel format.
+//
+// The returned values indicate how much chroma is downsampled relative to
+// luma, expressed as base-2 logarithms. For example, many 4:2:0 formats return
+// hShift == 1 and vShift == 1, meaning chroma has half the resolution
+// horizontally and vertically.
+//
+// If the pixel format is invalid, an error wrapping ErrUnknownPixelFormat
+// and the libavutil AVError is returned.
+func PixFmtGetChromaSubSample(pf PixelFormat) (hShift int, vShift int, err error) {
+	desc := goPixFmtDescGet(pf)
+	if desc == nil {
+		return 0, 0, avErrorf(ErrUnknownPixelFormat, averrorENOSYS,
+			"%d", int(pf))
+	}
+	return desc.log2ChromaW, desc.log2ChromaH, nil
+}
+
+// PixFmtCountPlanes returns the number of image planes used by the given pixel
+// format.
+//
+// For example, packed RGB formats use a single plane, planar YUV formats often
+// use three planes, and certain specialized formats may have more.
+//
+// If the pixel format is invalid, an error wrapping ErrUnknownPixelFormat
+// and the libavutil AVError is returned.
+func PixFmtCountPlanes(pf PixelFormat) (int, error) {
+	ret := goPixFmtCountPlanes(pf)
+	if ret < 0 {
+		return 0, avErrorf(ErrUnknownPixelFormat, ret, "%d", int(pf))
+	}
+	return ret, nil
+}
+
+// ColorRangeName returns the canonical name of a color range value.
+//
+// Color range describes how pixel values map to light intensities. For
+// example, "limited" or "tv" ranges reserve headroom for broadcast safety,
+// while "full" or "pc" ranges use the entire value range.
+//
+// If the value is unrecognized, an empty string is returned.
+func ColorRangeName(r int) string {
+	return goColorName(colorRangeNames[:], r)
+}
+
+// ColorRangeFromName converts a color range name into its corresponding value.
+//
+// Recognized names include "limited", "full", "tv", "pc", and similar standard
+// ranges. The lookup is case-insensitive.
+//
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName.
+func ColorRangeFromName(name string) (int, error) {
+	if name == "" {
+		return -1, ErrInvalidArgument
+	}
+	ret := goColorFromName(colorRangeNames[:], name)
+	if ret < 0 {
+		return ret, avErrorf(ErrUnknownColorName, ret,
+			"color range %q", name)
+	}
+	return ret, nil
+}
+
+// ColorPrimariesName returns the descriptive name for a given color primaries
+// value.
+//
+// Color primaries define the RGB chromaticity coordinates used by a format,
+// such as BT.709, BT.2020, or SMPTE 240M. They affect color reproduction and
+// gamut conversion.
+//
+// If the value is unrecognized, an empty string is returned.
+func ColorPrimariesName(p int) string {
+	return goColorName(colorPrimariesNames[:], p)
+}
+
+// ColorPrimariesFromName converts a color primaries name into its
+// corresponding value.
+//
+// Recognized names include standard sets like "BT.709", "BT.2020", and "SMPTE
+// 170M". The lookup is case-insensitive.
+//
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName. A successful call returns the integer identifier for
+// the primaries.
+func ColorPrimariesFromName(name string) (int, error) {
+	if name == "" {
+		return -1, ErrInvalidArgument
+	}
+	ret := goColorFromName(colorPrimariesNames[:], name)
+	if ret < 0 {
+		return ret, avErrorf(ErrUnknownColorName, ret,
+			"color primaries %q", name)
+	}
+	return ret, nil
+}
+
+// ColorTransferName returns the name associated with a given color transfer
+// characteristic.
+//
+// Color transfer characteristics define how digital values map to physical
+// light, including gamma curves, HDR transfer functions, HLG, and legacy
+// power-law encodings.
+//
+// If the value is unrecognized, an empty string is returned.
+func ColorTransferName(t int) string {
+	return goColorName(colorTransferNames[:], t)
+}
+
+// ColorTransferFromName converts a color transfer characteristic name into
+// its corresponding value.
+//
+// Recognized names include standard transfer curves like "bt709", "smpte240m",
+// "arib-std-b67" (HLG), and "smpte2084" (PQ). The lookup is case-insensitive.
+//
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName. A successful call returns the integer identifier for
+// the transfer characteristic.
+func ColorTransferFromName(name string) (int, error) {
+	if name == "" {
+		return -1, ErrInvalidArgument
+	}
+	ret := goColorFromName(colorTransferNames[:], name)
+	if ret < 0 {
+		return ret, avErrorf(ErrUnknownColorName, ret,
+			"color transfer %q", name)
+	}
+	return ret, nil
+}
+
+// ColorSpaceName returns the name associated with a given color space value.
+//
+// A color space defines how RGB channels are converted to or from luma and
+// chroma components. Standard examples include BT.601, BT.709, and BT.2020.
+//
+// If the value is unrecognized, an empty string is returned.
+func ColorSpaceName(s int) string {
+	return goColorName(colorSpaceNames[:], s)
+}
+
+// ColorSpaceFromName converts a color-space name into its corresponding value.
+//
+// Recognized names include standard color spaces like "BT.601", "BT.709", and
+// "BT.2020". The lookup is case-insensitive.
+//
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName. A successful call returns the integer identifier for
+// the color space.
+func ColorSpaceFromName(name string) (int, error) {
+	if name == "" {
+		return -1, ErrInvalidArgument
+	}
+	ret := goColorFromName(colorSpaceNames[:], name)
+	if ret < 0 {
+		return ret, avErrorf(ErrUnknownColorName, ret,
+			"color space %q", name)
+	}
+	return ret, nil
+}
+
+// ChromaLocationName returns the name associated with a given chroma location
+// value.
+//
+// Chroma location defines the spatial alignment of chroma samples relative to
+// luma samples in a subsampled image format (e.g., 4:2:0). Common names
+// include "left", "center", and "top-left".
+//
+// If the value is unrecognized, an empty string is returned.
+func ChromaLocationName(loc int) string {
+	return goColorName(chromaLocationNames[:], loc)
+}
+
+// ChromaLocationFromName converts a chroma location name into its
+// corresponding value.
+//
+// Recognized names include standard locations such as "left", "center", and
+// "top-left". The lookup is case-insensitive.
+//
+// An empty name returns ErrInvalidArgument and an unrecognized one returns
+// ErrUnknownColorName. A successful call returns the integer identifier for
+// the chroma location.
+func ChromaLocationFromName(name string) (int, error) {
+	if name == "" {
+		return -1, ErrInvalidArgument
+	}
+	ret := goColorFromName(chromaLocationNames[:], name)
+	if ret < 0 {
+		return ret, avErrorf(ErrUnknownColorName, ret,
+			"chroma location %q", name)
+	}
+	return ret, nil
+}
+
+// ChromaLocationEnumToPos converts a chroma-location enumeration value into
+// its (x, y) sample position offsets.
+//
+// The returned xpos and ypos values indicate the horizontal and vertical
+// placement of chroma samples relative to the top-left corner of a luma
+// sample. If the enumeration is unrecognized, the function returns an error
+// wrapping ErrInvalidArgument and the libavutil AVError.
+func ChromaLocationEnumToPos(pos int) (xpos, ypos int, err error) {
+	x, y, ret := goChromaLocationEnumToPos(pos)
+	if ret < 0 {
+		return 0, 0, avErrorf(ErrInvalidArgument, ret,
+			"chroma location %d", pos)
+	}
+	return x, y, nil
+}
+
+// ChromaLocationPosToEnum converts chroma sample coordinates into the
+// corresponding AVChromaLocation enumeration value.
+//
+// xpos and ypos specify the horizontal and vertical chroma sample offsets.
+// The function returns the enumeration value corresponding to these offsets.
+func ChromaLocationPosToEnum(xpos, ypos int) (int, error) {
+	return goChromaLocationPosToEnum(xpos, ypos), nil
+}
+
+// GetPixFmt retrieves the FFmpeg pixel-format enumeration corresponding to
+// a textual name.
+//
+// Pixel formats define how pixel data is stored in memory, including bit depth
+// and component ordering (e.g., "yuv420p", "rgb24").
+//
+// An empty name returns ErrInvalidArgument and an unknown one returns
+// ErrUnknownPixelFormat.
+// On success, the function returns the corresponding PixelFormat value.
+func GetPixFmt(name string) (PixelFormat, error) {
+	if name == "" {
+		return PixFmtNone, ErrInvalidArgument
+	}
+	ret := goGetPixFmt(name)
+	if ret == PixFmtNone {
+		return ret, fmt.Errorf("%w: %q", ErrUnknownPixelFormat, name)
+	}
+	return ret, nil
+}
+
+// GetPixFmtName returns the canonical FFmpeg name for a given PixelFormat
+// enumeration value.
+//
+// If the format is unrecognized, an empty string is returned.
+func GetPixFmtName(pf PixelFormat) string {
+	return goGetPixFmtName(pf)
+}
+
+// GetPixFmtString returns a detailed string describing the pixel format.
+//
+// The returned string includes the format name, bit depth, component layout,
+// and other descriptive information. This is similar to FFmpeg’s av_get_pix_fmt_string.
+func GetPixFmtString(pf PixelFormat) (string, error) {
+	return goGetPixFmtString(pf), nil
+}
+
+// ---------------- Image line read/write ----------------
+
+// ReadImageLine2 reads a horizontal line of pixel data from an image into a
+// destination buffer, supporting planar and paletted formats.
+//
+// Without cgo this is ReadImageLine2Native, which follows the libavutil
+// implementation bit for bit.
+func ReadImageLine2(dst []byte, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c, w int, readPalComponent bool,
+	dstElementSize int) error {
+	if desc == nil || desc.desc == nil {
+		return ErrInvalidArgument
+	}
+	return ReadImageLine2Native(dst, data, linesize, desc, x, y, c, w,
+		readPalComponent, dstElementSize)
+}
+
+// ReadImageLine reads a horizontal line of pixel data from an image into a
+// uint16 destination buffer, supporting planar and paletted formats.
+//
+// Without cgo this is ReadImageLineNative, which follows the libavutil
+// implementation bit for bit.
+func ReadImageLine(dst []uint16, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c, w int, readPalComponent bool) error {
+	if desc == nil || desc.desc == nil {
+		return ErrInvalidArgument
+	}
+	return ReadImageLineNative(dst, data, linesize, desc, x, y, c, w,
+		readPalComponent)
+}
+
+// WriteImageLine2 writes a horizontal line of pixel data from a byte slice
+// into an image, supporting planar and packed formats.
+//
+// Without cgo this is WriteImageLine2Native, which follows the libavutil
+// implementation bit for bit.
+func WriteImageLine2(src []byte, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c, w int, srcElementSize int) error {
+	if desc == nil || desc.desc == nil {
+		return ErrInvalidArgument
+	}
+	return WriteImageLine2Native(src, data, linesize, desc, x, y, c, w,
+		srcElementSize)
+}
+
+// WriteImageLine writes a horizontal line of pixel data from a uint16 slice
+// into an image, supporting planar and packed formats.
+//
+// Without cgo this is WriteImageLineNative, which follows the libavutil
+// implementation bit for bit.
+func WriteImageLine(src []uint16, data [4][]byte, linesize [4]int,
+	desc *PixFmtDescRef, x, y, c, w int) error {
+	if desc == nil || desc.desc == nil {
+		return ErrInvalidArgument
+	}
+	return WriteImageLineNative(src, data, linesize, desc, x, y, c, w)
+}
+
+// ---------------- Endianness swap ----------------
+
+// PixFmtSwapEndianness returns the pixel format with swapped endianness for
+// the given PixelFormat.
+//
+// Some pixel formats have different memory layouts depending on the system’s
+// endianness. This function returns the equivalent format with the opposite
+// endianness. If no swapped-endianness version exists, ErrNoEndianSwap is
+// returned.
+func PixFmtSwapEndianness(pf PixelFormat) (PixelFormat, error) {
+	ret := goPixFmtSwapEndianness(pf)
+	if ret == PixFmtNone {
+		return ret, fmt.Errorf("%w: %d", ErrNoEndianSwap, int(pf))
+	}
+	return ret, nil
+}
+
+// ---------------- Loss & best-format selection ----------------
+
+// GetPixFmtLoss returns the information lost when converting from the
+// source pixel format to the destination pixel format.
+//
+// dst and src are the destination and source PixelFormat values, respectively.
+// hasAlpha indicates whether the alpha channel should be considered in the
+// comparison.
+//
+// The returned Loss is a set of FF_LOSS_* bits, 0 meaning no loss. Unknown
+// formats return ErrUnknownPixelFormat, and hardware accelerated formats or
+// formats without components, which libavutil cannot compare, return
+// ErrInvalidArgument.
+func GetPixFmtLoss(dst, src PixelFormat, hasAlpha bool) (Loss, error) {
+	ret := goGetPixFmtLoss(dst, src, hasAlpha)
+	if ret < 0 {
+		return 0, lossError(ret)
+	}
+	return Loss(ret), nil
+}
+
+// FindBestPixFmtOf2 returns the best pixel format from two candidates for
+// converting a source pixel format, along with the associated loss.
+//
+// dst1 and dst2 are candidate destination PixelFormats. src is the source
+// PixelFormat. hasAlpha indicates whether the alpha channel should be
+// considered.
+//
+// The function returns the chosen PixelFormat, its FF_LOSS_* bits, and
+// ErrNoSuitableFormat if no suitable format could be found. The loss value
+// quantifies the difference between the source and selected destination
+// format.
+func FindBestPixFmtOf2(dst1, dst2, src PixelFormat, hasAlpha bool) (best PixelFormat, loss Loss, err error) {
+	best, ret := goFindBestPixFmtOf2(dst1, dst2, src, hasAlpha)
+	if best == PixFmtNone {
+		return best, Loss(ret), ErrNoSuitableFormat
+	}
+	return best, Loss(ret), nil
+}
+
+// ---------------- Errors ----------------
+
+// avErrorStrings holds what av_strerror reports for the codes the pure-Go
+// routines return.
+var avErrorStrings = map[int]string{
+	averrorEINVAL: "Invalid argument",
+	averrorENOSYS: "Function not implemented",
+}
+
+// avStrError mirrors av_strerror for the codes in avErrorStrings, falling
+// back to its message for unknown codes.
+func avStrError(code int) string {
+	if s, ok := avErrorStrings[code]; ok {
+		return s
+	}
+	return fmt.Sprintf("Error number %d occurred", code)
+}
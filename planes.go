@@ -0,0 +1,123 @@
+package gopixfmts
+
+import "math"
+
+// planeMaxSteps returns, for every plane, the largest component step found in
+// that plane along with the index of the component that has it. This follows
+// av_image_fill_max_pixsteps.
+func planeMaxSteps(desc *PixFmtDescRef) (steps, comps [4]int) {
+	for i := 0; i < 4; i++ {
+		comp, err := desc.Component(i)
+		if err != nil {
+			continue
+		}
+		if comp.Step > steps[comp.Plane] {
+			steps[comp.Plane] = comp.Step
+			comps[comp.Plane] = i
+		}
+	}
+	return
+}
+
+// planeShifts returns the horizontal and vertical subsampling shifts used for
+// the given plane. Only planes 1 and 2 carry chroma.
+func planeShifts(desc *PixFmtDescRef, plane int) (sw, sh int) {
+	if plane == 1 || plane == 2 {
+		return desc.Log2ChromaW(), desc.Log2ChromaH()
+	}
+	return 0, 0
+}
+
+// shiftCeil divides n by 2^s rounding up.
+func shiftCeil(n, s int) int {
+	return (n + (1 << s) - 1) >> s
+}
+
+// alignUp rounds n up to the next multiple of align. An align of 0 or 1
+// leaves n untouched.
+func alignUp(n, align int) int {
+	if align <= 1 {
+		return n
+	}
+	return (n + align - 1) / align * align
+}
+
+// hasPlanes reports which of the 4 data planes carry component data.
+func hasPlanes(desc *PixFmtDescRef) (has [4]bool) {
+	for i := 0; i < desc.NbComponents(); i++ {
+		comp, err := desc.Component(i)
+		if err != nil {
+			continue
+		}
+		has[comp.Plane] = true
+	}
+	return
+}
+
+// fillLinesizes computes the minimal linesize of every plane for an image of
+// the given width, following av_image_fill_linesizes. Unused planes report 0.
+func fillLinesizes(desc *PixFmtDescRef, width int) ([4]int, error) {
+	var linesize [4]int
+	if desc == nil || width < 0 {
+		return linesize, ErrInvalidArgument
+	}
+	if desc.Flags().Has(PixFmtFlagHWAccel) {
+		return linesize, ErrInvalidArgument
+	}
+
+	steps, comps := planeMaxSteps(desc)
+	for i := 0; i < 4; i++ {
+		s := 0
+		if comps[i] == 1 || comps[i] == 2 {
+			s = desc.Log2ChromaW()
+		}
+		w := shiftCeil(width, s)
+		if w > 0 && steps[i] > math.MaxInt32/w {
+			return linesize, ErrInvalidArgument
+		}
+		linesize[i] = steps[i] * w
+		if desc.Flags().Has(PixFmtFlagBitstream) {
+			linesize[i] = (linesize[i] + 7) >> 3
+		}
+	}
+	return linesize, nil
+}
+
+// fillPlaneSizes computes the byte size of every plane for an image of the
+// given height and linesizes, following av_image_fill_plane_sizes. Paletted
+// formats get a PaletteSiz byte palette in plane 1.
+//
+// Sizes whose sum would overflow an int return ErrInvalidArgument, so the
+// planes can always be allocated back to back.
+func fillPlaneSizes(desc *PixFmtDescRef, height int,
+	linesize [4]int) ([4]int, error) {
+	var sizes [4]int
+	if desc == nil || height < 0 {
+		return sizes, ErrInvalidArgument
+	}
+
+	if height > 0 && linesize[0] > math.MaxInt/height {
+		return sizes, ErrInvalidArgument
+	}
+	sizes[0] = linesize[0] * height
+	if desc.Flags().Has(PixFmtFlagPAL) {
+		if sizes[0] > math.MaxInt-PaletteSiz {
+			return sizes, ErrInvalidArgument
+		}
+		sizes[1] = PaletteSiz
+		return sizes, nil
+	}
+
+	total := sizes[0]
+	has := hasPlanes(desc)
+	for i := 1; i < 4 && has[i]; i++ {
+		_, sh := planeShifts(desc, i)
+		h := shiftCeil(height, sh)
+		if linesize[i] > 0 && h > (math.MaxInt-total)/linesize[i] {
+			return sizes, ErrInvalidArgument
+		}
+		sizes[i] = h * linesize[i]
+		total += sizes[i]
+	}
+	return sizes, nil
+}
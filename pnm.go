@@ -0,0 +1,399 @@
+package gopixfmts
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPNM is returned, wrapped with the reason, when a PNM or PAM
+// stream is malformed or of a kind this package does not read.
+var ErrInvalidPNM = errors.New("invalid pnm stream")
+
+// PAM tuple types written by EncodePNM and understood by DecodePNM.
+const (
+	pamGray      = "GRAYSCALE"
+	pamGrayAlpha = "GRAYSCALE_ALPHA"
+	pamRGB       = "RGB"
+	pamRGBAlpha  = "RGB_ALPHA"
+)
+
+// ---------------- Export ----------------
+
+// EncodePNM writes the frame to w as a binary PNM or PAM image.
+//
+// Gray frames become PGM and color frames PPM. Frames with alpha become PAM
+// with the GRAYSCALE_ALPHA or RGB_ALPHA tuple type. The maxval follows the
+// deepest component, so 8-bit formats are written with maxval 255, 10-bit
+// formats with 1023 and 16-bit formats such as gray16 or rgb48 with 65535,
+// in which case every integer RGB or gray sample is stored unchanged.
+// Components shallower than the deepest one are scaled up.
+//
+// YUV frames are converted to RGB with the BT.601 matrix, limited range
+// except for the yuvj formats, at the depth of the frame. Floating point
+// and deeper than 16-bit formats are written with maxval 65535 and paletted
+// formats with maxval 255.
+//
+// Hardware accelerated, Bayer and XYZ formats return ErrInvalidArgument.
+func EncodePNM(w io.Writer, f *Frame) error {
+	if f == nil {
+		return ErrInvalidArgument
+	}
+	kind, err := imageKindOf(f.desc)
+	if err != nil {
+		return err
+	}
+	n := f.desc.NbComponents()
+	alpha := f.desc.HasAlpha()
+	channels := 3
+	if kind == imageGray {
+		channels = 1
+	}
+
+	depth := 0
+	for c := 0; c < n; c++ {
+		comp, _ := f.desc.Component(c)
+		depth = max(depth, comp.Depth)
+	}
+
+	var planes [][]uint16
+	if (kind == imageRGB || kind == imageGray) && !f.desc.IsFloat() &&
+		depth <= 16 {
+		planes, err = pnmRawPlanes(f, channels, alpha, depth)
+	} else {
+		switch {
+		case f.desc.IsFloat() || depth > 16:
+			depth = 16
+		case kind == imagePaletted:
+			depth = 8
+		}
+		planes, err = pnmNormalizedPlanes(f, kind, channels, alpha, depth)
+	}
+	if err != nil {
+		return err
+	}
+
+	maxval := 1<<depth - 1
+	var header string
+	switch {
+	case !alpha && channels == 1:
+		header = fmt.Sprintf("P5\n%d %d\n%d\n", f.width, f.height, maxval)
+	case !alpha:
+		header = fmt.Sprintf("P6\n%d %d\n%d\n", f.width, f.height, maxval)
+	default:
+		tuple := pamRGBAlpha
+		if channels == 1 {
+			tuple = pamGrayAlpha
+		}
+		header = fmt.Sprintf("P7\nWIDTH %d\nHEIGHT %d\nDEPTH %d\nMAXVAL "+
+			"%d\nTUPLTYPE %s\nENDHDR\n", f.width, f.height, len(planes),
+			maxval, tuple)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(header)
+	for i := 0; i < f.width*f.height; i++ {
+		for _, p := range planes {
+			if maxval > 0xff {
+				bw.WriteByte(byte(p[i] >> 8))
+			}
+			bw.WriteByte(byte(p[i]))
+		}
+	}
+	return bw.Flush()
+}
+
+// pnmRawPlanes reads the components of an integer RGB or gray frame as they
+// are stored, scaling components shallower than depth up to it.
+func pnmRawPlanes(f *Frame, channels int, alpha bool,
+	depth int) ([][]uint16, error) {
+	comps := channels
+	if alpha {
+		comps++
+	}
+	planes := make([][]uint16, comps)
+	maxv := uint32(1)<<depth - 1
+	for c := range planes {
+		s, err := ReadComponent(f, c)
+		if err != nil {
+			return nil, err
+		}
+		comp, _ := f.desc.Component(c)
+		if f.format == PixFmtMonoWhite {
+			for i, v := range s.Samples {
+				s.Samples[i] = 1 - v
+			}
+		}
+		if comp.Depth != depth {
+			cmax := uint32(1)<<comp.Depth - 1
+			for i, v := range s.Samples {
+				s.Samples[i] = uint16((uint32(v)*maxv + cmax/2) / cmax)
+			}
+		}
+		planes[c] = s.Samples
+	}
+	return planes, nil
+}
+
+// pnmNormalizedPlanes reads f through normalized planes, converting YUV
+// and paletted frames to RGB, and quantizes them to depth bits.
+func pnmNormalizedPlanes(f *Frame, kind imageKind, channels int, alpha bool,
+	depth int) ([][]uint16, error) {
+	p, err := loadFrame(f)
+	if err != nil {
+		return nil, err
+	}
+	if kind == imageYCbCr {
+		xpos, ypos, _ := goChromaLocationEnumToPos(int(ChromaLocationLeft))
+		p = p.toRGB(xpos, ypos)
+	}
+	src := append([][]float32(nil), p.c[:channels]...)
+	if alpha {
+		if p.c[3] == nil {
+			p.c[3] = make([]float32, p.w*p.h)
+			for i := range p.c[3] {
+				p.c[3][i] = 1
+			}
+		}
+		src = append(src, p.c[3])
+	}
+	planes := make([][]uint16, len(src))
+	for c, s := range src {
+		planes[c] = make([]uint16, len(s))
+		for i, v := range s {
+			planes[c][i] = uint16(encodeNormalized(depth, false, float64(v)))
+		}
+	}
+	return planes, nil
+}
+
+// ---------------- Import ----------------
+
+// pnmFormats lists, per tuple type, the pixel formats DecodePNM picks for
+// the maxvals it stores unchanged, keyed by their bit depth.
+var pnmFormats = map[string]map[int]PixelFormat{
+	pamGray: {8: PixFmtGray8, 9: PixFmtGRAY9LE, 10: PixFmtGRAY10LE,
+		12: PixFmtGRAY12LE, 14: PixFmtGRAY14LE, 16: PixFmtGray16LE},
+	pamGrayAlpha: {8: PixFmtYA8, 16: PixFmtYA16LE},
+	pamRGB: {8: PixFmtRGB24, 9: PixFmtGBRP9LE, 10: PixFmtGBRP10LE,
+		12: PixFmtGBRP12LE, 14: PixFmtGBRP14LE, 16: PixFmtRGB48LE},
+	pamRGBAlpha: {8: PixFmtRGBA, 10: PixFmtGBRAP10LE, 12: PixFmtGBRAP12LE,
+		14: PixFmtGBRAP14LE, 16: PixFmtRGBA64LE},
+}
+
+// pnmHeader holds the parsed header of a binary PNM or PAM image.
+type pnmHeader struct {
+	width, height int
+	depth         int
+	maxval        int
+	tuple         string
+}
+
+// DecodePNM reads a binary PGM (P5), PPM (P6) or PAM (P7) image into a new
+// frame.
+//
+// The frame's format follows the image: gray, gray with alpha, RGB or RGBA,
+// at the bit depth of the maxval when a format of that depth exists, such as
+// gray10le for maxval 1023, gbrp12le for an RGB maxval of 4095 or rgb48le
+// for 65535. Other maxvals are rescaled to 8 or 16 bits. Images written by
+// EncodePNM from integer RGB or gray frames therefore load back losslessly.
+// Use Convert to move the frame into another format.
+//
+// Malformed images, sizes too large to address, ASCII variants and PAM
+// tuple types other than GRAYSCALE, GRAYSCALE_ALPHA, RGB, RGB_ALPHA and
+// BLACKANDWHITE return ErrInvalidPNM.
+func DecodePNM(r io.Reader) (*Frame, error) {
+	br := bufio.NewReader(r)
+	h, err := readPNMHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	depth := bits.Len(uint(h.maxval))
+	pf, ok := pnmFormats[h.tuple][depth]
+	if !ok || h.maxval != 1<<depth-1 {
+		depth = 8
+		if h.maxval > 0xff {
+			depth = 16
+		}
+		pf = pnmFormats[h.tuple][depth]
+	}
+	f, err := NewFrame(pf, h.width, h.height, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	size := 1
+	if h.maxval > 0xff {
+		size = 2
+	}
+	raster := make([]byte, h.width*h.height*h.depth*size)
+	if _, err := io.ReadFull(br, raster); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	maxv := uint32(1)<<depth - 1
+	for c := 0; c < h.depth; c++ {
+		s := &ComponentSamples{Width: h.width, Height: h.height,
+			Stride: h.width, Samples: make([]uint16, h.width*h.height)}
+		for i := range s.Samples {
+			off := (i*h.depth + c) * size
+			v := uint32(raster[off])
+			if size == 2 {
+				v = v<<8 | uint32(raster[off+1])
+			}
+			v = min(v, uint32(h.maxval))
+			if uint32(h.maxval) != maxv {
+				v = (v*maxv + uint32(h.maxval)/2) / uint32(h.maxval)
+			}
+			s.Samples[i] = uint16(v)
+		}
+		if err := WriteComponent(f, c, s); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// readPNMHeader parses the header of a binary PNM or PAM image, leaving br
+// at the first raster byte.
+func readPNMHeader(br *bufio.Reader) (pnmHeader, error) {
+	var magic [2]byte
+	if _, err := io.ReadFull(br, magic[:]); err != nil {
+		return pnmHeader{}, fmt.Errorf("%w: missing magic", ErrInvalidPNM)
+	}
+	var h pnmHeader
+	switch string(magic[:]) {
+	case "P5", "P6":
+		var v [3]int
+		for i := range v {
+			tok, err := readPNMToken(br)
+			if err != nil {
+				return pnmHeader{}, err
+			}
+			if v[i], err = strconv.Atoi(tok); err != nil {
+				return pnmHeader{}, fmt.Errorf("%w: bad header value %q",
+					ErrInvalidPNM, tok)
+			}
+		}
+		h = pnmHeader{width: v[0], height: v[1], depth: 1, maxval: v[2],
+			tuple: pamGray}
+		if magic[1] == '6' {
+			h.depth, h.tuple = 3, pamRGB
+		}
+	case "P7":
+		var err error
+		if h, err = readPAMHeader(br); err != nil {
+			return pnmHeader{}, err
+		}
+	default:
+		return pnmHeader{}, fmt.Errorf("%w: unsupported magic %q",
+			ErrInvalidPNM, magic[:])
+	}
+	// Bound the size like av_image_check_size before anything is allocated.
+	if checkImageSize(h.width, h.height) != nil || h.maxval <= 0 ||
+		h.maxval > 0xffff {
+		return pnmHeader{}, fmt.Errorf("%w: bad header %dx%d maxval %d",
+			ErrInvalidPNM, h.width, h.height, h.maxval)
+	}
+	return h, nil
+}
+
+// readPNMToken returns the next whitespace separated header token, skipping
+// comments. The single whitespace byte ending the token is consumed.
+func readPNMToken(br *bufio.Reader) (string, error) {
+	var tok []byte
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return "", fmt.Errorf("%w: truncated header", ErrInvalidPNM)
+		}
+		switch {
+		case c == '#' && len(tok) == 0:
+			if _, err := br.ReadString('\n'); err != nil {
+				return "", fmt.Errorf("%w: truncated header", ErrInvalidPNM)
+			}
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' ||
+			c == '\v' || c == '\f':
+			if len(tok) > 0 {
+				return string(tok), nil
+			}
+		default:
+			tok = append(tok, c)
+		}
+	}
+}
+
+// readPAMHeader parses the KEY value lines of a PAM header up to ENDHDR.
+func readPAMHeader(br *bufio.Reader) (pnmHeader, error) {
+	var h pnmHeader
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return pnmHeader{}, fmt.Errorf("%w: truncated header",
+				ErrInvalidPNM)
+		}
+		key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+		value = strings.TrimSpace(value)
+		var v int
+		switch key {
+		case "", "#":
+			continue
+		case "ENDHDR":
+			return h, checkPAMTuple(&h)
+		case "TUPLTYPE":
+			h.tuple = value
+			continue
+		case "WIDTH", "HEIGHT", "DEPTH", "MAXVAL":
+			if v, err = strconv.Atoi(value); err != nil {
+				return pnmHeader{}, fmt.Errorf("%w: bad %s %q", ErrInvalidPNM,
+					key, value)
+			}
+		default:
+			if strings.HasPrefix(key, "#") {
+				continue
+			}
+			return pnmHeader{}, fmt.Errorf("%w: unknown header line %q",
+				ErrInvalidPNM, key)
+		}
+		switch key {
+		case "WIDTH":
+			h.width = v
+		case "HEIGHT":
+			h.height = v
+		case "DEPTH":
+			h.depth = v
+		case "MAXVAL":
+			h.maxval = v
+		}
+	}
+}
+
+// checkPAMTuple resolves the tuple type of h, inferring it from the depth
+// when absent, and checks that the two agree.
+func checkPAMTuple(h *pnmHeader) error {
+	depths := map[string]int{pamGray: 1, "BLACKANDWHITE": 1,
+		pamGrayAlpha: 2, pamRGB: 3, pamRGBAlpha: 4}
+	if h.tuple == "" {
+		for t := range pnmFormats {
+			if depths[t] == h.depth {
+				h.tuple = t
+			}
+		}
+	}
+	if h.tuple == "BLACKANDWHITE" {
+		h.tuple = pamGray
+	}
+	if d, ok := depths[h.tuple]; !ok || d != h.depth {
+		return fmt.Errorf("%w: tuple type %q with depth %d", ErrInvalidPNM,
+			h.tuple, h.depth)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package gopixfmts_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_PNM_RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, tc := range []struct {
+		pf     pixfmts.PixelFormat
+		header string
+	}{
+		{pixfmts.PixFmtGray8, "P5\n3 2\n255\n"},
+		{pixfmts.PixFmtGray16LE, "P5\n3 2\n65535\n"},
+		{pixfmts.PixFmtGRAY10LE, "P5\n3 2\n1023\n"},
+		{pixfmts.PixFmtRGB24, "P6\n3 2\n255\n"},
+		{pixfmts.PixFmtRGB48LE, "P6\n3 2\n65535\n"},
+		{pixfmts.PixFmtGBRP12LE, "P6\n3 2\n4095\n"},
+		{pixfmts.PixFmtRGBA64LE, "P7\nWIDTH 3\nHEIGHT 2\nDEPTH 4\n" +
+			"MAXVAL 65535\nTUPLTYPE RGB_ALPHA\nENDHDR\n"},
+		{pixfmts.PixFmtYA8, "P7\nWIDTH 3\nHEIGHT 2\nDEPTH 2\n" +
+			"MAXVAL 255\nTUPLTYPE GRAYSCALE_ALPHA\nENDHDR\n"},
+	} {
+		src := newFrame(t, tc.pf, 3, 2)
+		for c := range src.Desc().NbComponents() {
+			comp, _ := src.Desc().Component(c)
+			s, err := pixfmts.ReadComponent(src, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range s.Samples {
+				s.Samples[i] = uint16(rng.Intn(1 << comp.Depth))
+			}
+			if err := pixfmts.WriteComponent(src, c, s); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var buf bytes.Buffer
+		if err := pixfmts.EncodePNM(&buf, src); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(buf.String(), tc.header) {
+			t.Fatalf("%v: unexpected header %q", tc.pf, buf.String())
+		}
+		got, err := pixfmts.DecodePNM(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Format() != tc.pf {
+			t.Fatalf("decoded %v as %v", tc.pf, got.Format())
+		}
+		equalComponents(t, got, src)
+	}
+}
+
+func Test_EncodePNM_YUV(t *testing.T) {
+	src := newFrame(t, pixfmts.PixFmtYUVA420P, 4, 2)
+	planes := src.Planes()
+	for i := range planes[0] {
+		planes[0][i], planes[3][i] = 235, 128
+	}
+	for i := range planes[1] {
+		planes[1][i], planes[2][i] = 128, 128
+	}
+	var buf bytes.Buffer
+	if err := pixfmts.EncodePNM(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+	const header = "P7\nWIDTH 4\nHEIGHT 2\nDEPTH 4\nMAXVAL 255\n" +
+		"TUPLTYPE RGB_ALPHA\nENDHDR\n"
+	want := header + strings.Repeat("\xff\xff\xff\x80", 8)
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func Test_DecodePNM(t *testing.T) {
+	f, err := pixfmts.DecodePNM(strings.NewReader(
+		"P5 # comment\n2 1\n# another\n100\n\x00\x64"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, _ := pixfmts.ReadComponent(f, 0)
+	if f.Format() != pixfmts.PixFmtGray8 || s.Samples[1] != 255 {
+		t.Fatalf("unexpected decode %v %v", f.Format(), s.Samples)
+	}
+
+	for _, in := range []string{"P2\n1 1\n255\n0\n", "P5\n1 1\n0\n",
+		"P7\nWIDTH 1\nHEIGHT 1\nDEPTH 2\nMAXVAL 255\nTUPLTYPE RGB\nENDHDR\n",
+		"P5\n3000000000 3000000000\n255\n", "P6\n65535 65535\n65535\n",
+		"P7\nWIDTH 3000000000\nHEIGHT 3000000000\nDEPTH 1\nMAXVAL 255\n" +
+			"TUPLTYPE GRAYSCALE\nENDHDR\n"} {
+		if _, err := pixfmts.DecodePNM(strings.NewReader(in)); !errors.Is(err,
+			pixfmts.ErrInvalidPNM) {
+			t.Fatalf("%q: expected invalid pnm, got %v", in, err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package gopixfmts
+
+// CIExy is a CIE 1931 xy chromaticity.
+type CIExy struct {
+	X, Y float64
+}
+
+// ColorPrimariesDesc holds the chromaticities of the red, green and blue
+// primaries and the white point of a set of color primaries.
+type ColorPrimariesDesc struct {
+	White CIExy
+	Red   CIExy
+	Green CIExy
+	Blue  CIExy
+}
+
+// Standard white points.
+var (
+	WhiteD65 = CIExy{0.3127, 0.3290}
+	WhiteC   = CIExy{0.310, 0.316}
+	WhiteDCI = CIExy{0.314, 0.351}
+	WhiteE   = CIExy{1.0 / 3, 1.0 / 3}
+)
+
+// colorPrimaries follows the table of av_csp_primaries_desc_from_id.
+var colorPrimaries = map[ColorPrimaries]ColorPrimariesDesc{
+	ColorPrimariesBT709: {WhiteD65, CIExy{0.640, 0.330},
+		CIExy{0.300, 0.600}, CIExy{0.150, 0.060}},
+	ColorPrimariesBT470M: {WhiteC, CIExy{0.670, 0.330},
+		CIExy{0.210, 0.710}, CIExy{0.140, 0.080}},
+	ColorPrimariesBT470BG: {WhiteD65, CIExy{0.640, 0.330},
+		CIExy{0.290, 0.600}, CIExy{0.150, 0.060}},
+	ColorPrimariesSMPTE170M: {WhiteD65, CIExy{0.630, 0.340},
+		CIExy{0.310, 0.595}, CIExy{0.155, 0.070}},
+	ColorPrimariesSMPTE240M: {WhiteD65, CIExy{0.630, 0.340},
+		CIExy{0.310, 0.595}, CIExy{0.155, 0.070}},
+	ColorPrimariesFilm: {WhiteC, CIExy{0.681, 0.319},
+		CIExy{0.243, 0.692}, CIExy{0.145, 0.049}},
+	ColorPrimariesBT2020: {WhiteD65, CIExy{0.708, 0.292},
+		CIExy{0.170, 0.797}, CIExy{0.131, 0.046}},
+	ColorPrimariesSMPTE428: {WhiteE, CIExy{0.735, 0.265},
+		CIExy{0.274, 0.718}, CIExy{0.167, 0.009}},
+	ColorPrimariesSMPTE431: {WhiteDCI, CIExy{0.680, 0.320},
+		CIExy{0.265, 0.690}, CIExy{0.150, 0.060}},
+	ColorPrimariesSMPTE432: {WhiteD65, CIExy{0.680, 0.320},
+		CIExy{0.265, 0.690}, CIExy{0.150, 0.060}},
+	ColorPrimariesEBU3213: {WhiteD65, CIExy{0.630, 0.340},
+		CIExy{0.295, 0.605}, CIExy{0.155, 0.077}},
+}
+
+// ColorPrimariesDescGet returns the chromaticities of a set of color
+// primaries, following av_csp_primaries_desc_from_id. Unspecified and
+// reserved values return ErrInvalidArgument.
+func ColorPrimariesDescGet(cp ColorPrimaries) (ColorPrimariesDesc, error) {
+	d, ok := colorPrimaries[cp]
+	if !ok {
+		return ColorPrimariesDesc{}, ErrInvalidArgument
+	}
+	return d, nil
+}
+
+// xyzOf returns the XYZ tristimulus of a chromaticity with Y = 1.
+func xyzOf(c CIExy) (x, y, z float64) {
+	return c.X / c.Y, 1, (1 - c.X - c.Y) / c.Y
+}
+
+// RGBToXYZMatrix returns the matrix converting linear RGB in the primaries
+// of d to CIE XYZ, scaled so that RGB white has Y = 1. Degenerate
+// chromaticities return ErrInvalidArgument.
+func RGBToXYZMatrix(d ColorPrimariesDesc) (Matrix3x3, error) {
+	for _, c := range []CIExy{d.White, d.Red, d.Green, d.Blue} {
+		if c.Y == 0 {
+			return Matrix3x3{}, ErrInvalidArgument
+		}
+	}
+	var m Matrix3x3
+	for i, c := range []CIExy{d.Red, d.Green, d.Blue} {
+		m[0][i], m[1][i], m[2][i] = xyzOf(c)
+	}
+	inv, err := m.Inverse()
+	if err != nil {
+		return Matrix3x3{}, err
+	}
+	s0, s1, s2 := inv.Apply(xyzOf(d.White))
+	for i := range m {
+		m[i][0] *= s0
+		m[i][1] *= s1
+		m[i][2] *= s2
+	}
+	return m, nil
+}
+
+// bradford is the Bradford cone response matrix.
+var bradford = Matrix3x3{
+	{0.8951, 0.2664, -0.1614},
+	{-0.7502, 1.7135, 0.0367},
+	{0.0389, -0.0685, 1.0296},
+}
+
+// BradfordAdaptation returns the matrix adapting CIE XYZ colors seen under
+// the white point src to the white point dst. Degenerate white points
+// return ErrInvalidArgument.
+func BradfordAdaptation(src, dst CIExy) (Matrix3x3, error) {
+	if src.Y == 0 || dst.Y == 0 {
+		return Matrix3x3{}, ErrInvalidArgument
+	}
+	sr, sg, sb := bradford.Apply(xyzOf(src))
+	dr, dg, db := bradford.Apply(xyzOf(dst))
+	scale := Matrix3x3{{dr / sr, 0, 0}, {0, dg / sg, 0}, {0, 0, db / sb}}
+	inv, err := bradford.Inverse()
+	if err != nil {
+		return Matrix3x3{}, err
+	}
+	return inv.Mul(scale).Mul(bradford), nil
+}
+
+// PrimariesConversionMatrix returns the matrix converting linear RGB in the
+// src primaries to linear RGB in the dst primaries. Differing white points
+// are Bradford adapted. Colors outside of the dst gamut come out negative
+// or above 1; gamut mapping them is left to the caller. Primaries without a
+// descriptor return ErrInvalidArgument.
+func PrimariesConversionMatrix(src, dst ColorPrimaries) (Matrix3x3, error) {
+	sd, err := ColorPrimariesDescGet(src)
+	if err != nil {
+		return Matrix3x3{}, err
+	}
+	dd, err := ColorPrimariesDescGet(dst)
+	if err != nil {
+		return Matrix3x3{}, err
+	}
+	toXYZ, err := RGBToXYZMatrix(sd)
+	if err != nil {
+		return Matrix3x3{}, err
+	}
+	fromXYZ, err := RGBToXYZMatrix(dd)
+	if err != nil {
+		return Matrix3x3{}, err
+	}
+	if fromXYZ, err = fromXYZ.Inverse(); err != nil {
+		return Matrix3x3{}, err
+	}
+	if sd.White != dd.White {
+		adapt, err := BradfordAdaptation(sd.White, dd.White)
+		if err != nil {
+			return Matrix3x3{}, err
+		}
+		toXYZ = adapt.Mul(toXYZ)
+	}
+	return fromXYZ.Mul(toXYZ), nil
+}
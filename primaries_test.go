@@ -0,0 +1,73 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func near(m, want pixfmts.Matrix3x3, eps float64) bool {
+	for i := range m {
+		for j := range m[i] {
+			if math.Abs(m[i][j]-want[i][j]) > eps {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func Test_RGBToXYZMatrix(t *testing.T) {
+	d, err := pixfmts.ColorPrimariesDescGet(pixfmts.ColorPrimariesBT709)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := pixfmts.RGBToXYZMatrix(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := pixfmts.Matrix3x3{
+		{0.4124, 0.3576, 0.1805},
+		{0.2126, 0.7152, 0.0722},
+		{0.0193, 0.1192, 0.9505},
+	}
+	if !near(m, want, 1e-4) {
+		t.Fatalf("unexpected BT.709 matrix: %v", m)
+	}
+}
+
+func Test_PrimariesConversionMatrix(t *testing.T) {
+	m, err := pixfmts.PrimariesConversionMatrix(pixfmts.ColorPrimariesBT709,
+		pixfmts.ColorPrimariesBT2020)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := pixfmts.Matrix3x3{
+		{0.6274, 0.3293, 0.0433},
+		{0.0691, 0.9195, 0.0114},
+		{0.0164, 0.0880, 0.8956},
+	}
+	if !near(m, want, 1e-4) {
+		t.Fatalf("unexpected BT.709 to BT.2020 matrix: %v", m)
+	}
+
+	// DCI-P3 white is adapted to D65 white.
+	m, err = pixfmts.PrimariesConversionMatrix(pixfmts.ColorPrimariesSMPTE431,
+		pixfmts.ColorPrimariesSMPTE432)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b := m.Apply(1, 1, 1); math.Abs(r-1)+math.Abs(g-1)+
+		math.Abs(b-1) > 1e-9 {
+		t.Fatalf("white maps to %v %v %v", r, g, b)
+	}
+
+	if _, err := pixfmts.PrimariesConversionMatrix(
+		pixfmts.ColorPrimariesUnspecified,
+		pixfmts.ColorPrimariesBT709); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
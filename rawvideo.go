@@ -0,0 +1,235 @@
+package gopixfmts
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// ---------------- Raw video layout ----------------
+
+// rawLayout describes how one frame of a headerless raw video stream is
+// laid out: every plane tightly packed with minimal linesizes and stored back
+// to back, like av_image_copy_to_buffer with an align of 1.
+type rawLayout struct {
+	format   PixelFormat
+	desc     *PixFmtDescRef
+	width    int
+	height   int
+	linesize [4]int
+	sizes    [4]int
+	size     int
+}
+
+func newRawLayout(pf PixelFormat, width, height int) (rawLayout, error) {
+	desc, err := PixFmtDescGet(pf)
+	if err != nil {
+		return rawLayout{}, err
+	}
+	if err := checkImageSize(width, height); err != nil {
+		return rawLayout{}, err
+	}
+	linesize, err := fillLinesizes(desc, width)
+	if err != nil {
+		return rawLayout{}, err
+	}
+	sizes, err := fillPlaneSizes(desc, height, linesize)
+	if err != nil {
+		return rawLayout{}, err
+	}
+	l := rawLayout{format: pf, desc: desc, width: width, height: height,
+		linesize: linesize, sizes: sizes}
+	for _, s := range sizes {
+		l.size += s
+	}
+	return l, nil
+}
+
+// frame wraps buf, which holds exactly one packed frame, in a Frame.
+func (l *rawLayout) frame(buf []byte) *Frame {
+	f := &Frame{format: l.format, desc: l.desc, width: l.width,
+		height: l.height, linesize: l.linesize}
+	off := 0
+	for i, s := range l.sizes {
+		if s == 0 {
+			continue
+		}
+		f.data[i] = buf[off : off+s : off+s]
+		off += s
+	}
+	return f
+}
+
+// pack copies the planes of f into buf, dropping any linesize padding.
+func (l *rawLayout) pack(buf []byte, f *Frame) {
+	off := 0
+	for i, s := range l.sizes {
+		if s == 0 {
+			continue
+		}
+		dst := buf[off : off+s]
+		off += s
+		if i == 1 && l.desc.Flags().Has(PixFmtFlagPAL) {
+			copy(dst, f.data[1][:PaletteSiz])
+			continue
+		}
+		n, stride := l.linesize[i], f.linesize[i]
+		if n == stride {
+			copy(dst, f.data[i][:s])
+			continue
+		}
+		for y := 0; y < s/n; y++ {
+			copy(dst[y*n:(y+1)*n], f.data[i][y*stride:])
+		}
+	}
+}
+
+// ---------------- Reader ----------------
+
+// RawVideoReader reads frames from a headerless raw video stream, such as a
+// .yuv or .rgb dump, whose frames are all of one pixel format and size.
+//
+// Frames are expected tightly packed, planes back to back with minimal
+// linesizes, which is how FFmpeg's rawvideo muxer writes them. Paletted
+// formats carry their PaletteSiz byte palette after the indexes.
+type RawVideoReader struct {
+	r      io.Reader
+	layout rawLayout
+}
+
+// NewRawVideoReader returns a reader for frames of the given pixel format and
+// size stored in r.
+//
+// If r also implements io.ReaderAt, frames can be read in any order with
+// ReadFrameAt. Hardware accelerated formats and sizes that are not positive
+// or too large to address return ErrInvalidArgument. Unknown formats return
+// ErrUnknownPixelFormat.
+func NewRawVideoReader(r io.Reader, pf PixelFormat, width,
+	height int) (*RawVideoReader, error) {
+	layout, err := newRawLayout(pf, width, height)
+	if err != nil {
+		return nil, err
+	}
+	return &RawVideoReader{r: r, layout: layout}, nil
+}
+
+// Format returns the pixel format of the frames.
+func (r *RawVideoReader) Format() PixelFormat { return r.layout.format }
+
+// Width returns the width of the frames in pixels.
+func (r *RawVideoReader) Width() int { return r.layout.width }
+
+// Height returns the height of the frames in pixels.
+func (r *RawVideoReader) Height() int { return r.layout.height }
+
+// FrameSize returns the number of bytes one frame takes in the stream.
+func (r *RawVideoReader) FrameSize() int { return r.layout.size }
+
+// ReadFrame reads the next frame of the stream into a newly allocated Frame.
+//
+// At the end of the stream it returns io.EOF, or io.ErrUnexpectedEOF if the
+// stream ends partway through a frame.
+func (r *RawVideoReader) ReadFrame() (*Frame, error) {
+	buf := make([]byte, r.layout.size)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		return nil, err
+	}
+	return r.layout.frame(buf), nil
+}
+
+// ReadFrameAt reads frame n, counting from 0, into a newly allocated Frame
+// without moving the position ReadFrame reads from.
+//
+// The underlying reader must implement io.ReaderAt, otherwise
+// ErrInvalidArgument is returned. Frames past the end of the stream return
+// io.EOF and truncated frames io.ErrUnexpectedEOF.
+func (r *RawVideoReader) ReadFrameAt(n int) (*Frame, error) {
+	ra, ok := r.r.(io.ReaderAt)
+	if !ok {
+		return nil, fmt.Errorf("%w: reader does not support random access",
+			ErrInvalidArgument)
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("%w: frame %d", ErrInvalidArgument, n)
+	}
+	buf := make([]byte, r.layout.size)
+	read, err := ra.ReadAt(buf, int64(n)*int64(r.layout.size))
+	switch {
+	case read == len(buf):
+		return r.layout.frame(buf), nil
+	case read > 0 && (err == nil || errors.Is(err, io.EOF)):
+		return nil, io.ErrUnexpectedEOF
+	}
+	return nil, err
+}
+
+// FrameCount returns the number of whole frames in the stream.
+//
+// The size of the stream is taken from a Size method, as provided by
+// bytes.Reader, strings.Reader and io.SectionReader, or a Stat method, as
+// provided by os.File. Other readers return ErrInvalidArgument.
+func (r *RawVideoReader) FrameCount() (int, error) {
+	var size int64
+	switch s := r.r.(type) {
+	case interface{ Size() int64 }:
+		size = s.Size()
+	case interface{ Stat() (fs.FileInfo, error) }:
+		fi, err := s.Stat()
+		if err != nil {
+			return 0, err
+		}
+		size = fi.Size()
+	default:
+		return 0, fmt.Errorf("%w: reader size is unknown", ErrInvalidArgument)
+	}
+	return int(size / int64(r.layout.size)), nil
+}
+
+// ---------------- Writer ----------------
+
+// RawVideoWriter writes frames to a headerless raw video stream in the
+// layout RawVideoReader reads.
+type RawVideoWriter struct {
+	w      io.Writer
+	layout rawLayout
+	buf    []byte
+	frames int
+}
+
+// NewRawVideoWriter returns a writer of frames of the given pixel format and
+// size to w. It fails like NewRawVideoReader.
+func NewRawVideoWriter(w io.Writer, pf PixelFormat, width,
+	height int) (*RawVideoWriter, error) {
+	layout, err := newRawLayout(pf, width, height)
+	if err != nil {
+		return nil, err
+	}
+	return &RawVideoWriter{w: w, layout: layout,
+		buf: make([]byte, layout.size)}, nil
+}
+
+// FrameSize returns the number of bytes one frame takes in the stream.
+func (w *RawVideoWriter) FrameSize() int { return w.layout.size }
+
+// Frames returns the number of frames written so far.
+func (w *RawVideoWriter) Frames() int { return w.frames }
+
+// WriteFrame appends f to the stream, dropping any linesize padding.
+//
+// Frames whose pixel format or size differ from the writer's return
+// ErrInvalidArgument.
+func (w *RawVideoWriter) WriteFrame(f *Frame) error {
+	l := &w.layout
+	if f.format != l.format || f.width != l.width || f.height != l.height {
+		return fmt.Errorf("%w: frame is %s %dx%d, writer takes %s %dx%d",
+			ErrInvalidArgument, GetPixFmtName(f.format), f.width, f.height,
+			GetPixFmtName(l.format), l.width, l.height)
+	}
+	l.pack(w.buf, f)
+	if _, err := w.w.Write(w.buf); err != nil {
+		return err
+	}
+	w.frames++
+	return nil
+}
@@ -0,0 +1,102 @@
+package gopixfmts_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"slices"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_RawVideo_RoundTrip(t *testing.T) {
+	const w, h = 5, 3
+	var buf bytes.Buffer
+	wr, err := pixfmts.NewRawVideoWriter(&buf, pixfmts.PixFmtYUV420P, w, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wr.FrameSize() != 15+2*3*2 {
+		t.Fatalf("unexpected frame size %d", wr.FrameSize())
+	}
+
+	rng := rand.New(rand.NewSource(5))
+	var frames []*pixfmts.Frame
+	for range 3 {
+		f := newFrame(t, pixfmts.PixFmtYUV420P, w, h)
+		for _, p := range f.Planes() {
+			rng.Read(p)
+		}
+		if err := wr.WriteFrame(f); err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, f)
+	}
+	if buf.Len() != 3*wr.FrameSize() {
+		t.Fatalf("wrote %d bytes", buf.Len())
+	}
+
+	// Drop the last byte so the final frame is truncated.
+	data := buf.Bytes()[:buf.Len()-1]
+	r, err := pixfmts.NewRawVideoReader(bytes.NewReader(data),
+		pixfmts.PixFmtYUV420P, w, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.FrameCount(); err != nil || n != 2 {
+		t.Fatalf("unexpected frame count %d, %v", n, err)
+	}
+
+	got, err := r.ReadFrameAt(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	equalComponents(t, got, frames[1])
+	if _, err := r.ReadFrameAt(2); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected unexpected EOF, got %v", err)
+	}
+	if _, err := r.ReadFrameAt(3); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+
+	for i := range 2 {
+		got, err := r.ReadFrame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		equalComponents(t, got, frames[i])
+	}
+	if _, err := r.ReadFrame(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected unexpected EOF, got %v", err)
+	}
+
+	if err := wr.WriteFrame(newFrame(t, pixfmts.PixFmtYUV420P, w+1,
+		h)); !errors.Is(err, pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+
+	if _, err := pixfmts.NewRawVideoReader(&buf, pixfmts.PixFmtYUV420P,
+		3000000000, 3000000000); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
+
+func equalComponents(t *testing.T, got, want *pixfmts.Frame) {
+	t.Helper()
+	for c := range want.Desc().NbComponents() {
+		a, err := pixfmts.ReadComponent(got, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := pixfmts.ReadComponent(want, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(a.Samples, b.Samples) {
+			t.Fatalf("component %d differs", c)
+		}
+	}
+}
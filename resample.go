@@ -0,0 +1,279 @@
+package gopixfmts
+
+import "math"
+
+// ChromaFilter selects the interpolation used when resampling chroma.
+type ChromaFilter int
+
+const (
+	// ChromaFilterNearest copies the closest chroma sample.
+	ChromaFilterNearest ChromaFilter = iota
+	// ChromaFilterBilinear interpolates linearly, and averages with a
+	// triangle filter when reducing.
+	ChromaFilterBilinear
+	// ChromaFilterCatmullRom uses the Catmull-Rom cubic spline.
+	ChromaFilterCatmullRom
+	// ChromaFilterLanczos uses a 3-lobed Lanczos window.
+	ChromaFilterLanczos
+)
+
+// kernel returns the weight function of the filter, defined over distances
+// in source samples, and its radius.
+func (f ChromaFilter) kernel() (func(float64) float64, float64) {
+	switch f {
+	case ChromaFilterCatmullRom:
+		return catmullRom, 2
+	case ChromaFilterLanczos:
+		return lanczos3, 3
+	}
+	return func(x float64) float64 { return 1 - math.Abs(x) }, 1
+}
+
+func catmullRom(x float64) float64 {
+	x = math.Abs(x)
+	switch {
+	case x < 1:
+		return 1.5*x*x*x - 2.5*x*x + 1
+	case x < 2:
+		return -0.5*x*x*x + 2.5*x*x - 4*x + 2
+	}
+	return 0
+}
+
+func lanczos3(x float64) float64 {
+	switch {
+	case x == 0:
+		return 1
+	case math.Abs(x) >= 3:
+		return 0
+	}
+	px := math.Pi * x
+	return 3 * math.Sin(px) * math.Sin(px/3) / (px * px)
+}
+
+// ChromaLayout describes how the chroma samples of a frame relate to its
+// luma samples: subsampled by 2^Log2ChromaW horizontally and 2^Log2ChromaH
+// vertically, and sited by Location. ChromaLocationUnspecified is treated
+// as ChromaLocationLeft.
+type ChromaLayout struct {
+	Log2ChromaW int
+	Log2ChromaH int
+	Location    ChromaLocation
+}
+
+// chromaLayoutOf returns the layout of desc's chroma sited at loc.
+func chromaLayoutOf(desc *PixFmtDescRef, loc ChromaLocation) ChromaLayout {
+	return ChromaLayout{desc.Log2ChromaW(), desc.Log2ChromaH(), loc}
+}
+
+// positions returns the sample positions of the layout in 1/256 units,
+// checking the subsampling factors.
+func (l ChromaLayout) positions() (xpos, ypos int, err error) {
+	if l.Log2ChromaW < 0 || l.Log2ChromaW > 2 || l.Log2ChromaH < 0 ||
+		l.Log2ChromaH > 2 {
+		return 0, 0, ErrInvalidArgument
+	}
+	loc := l.Location
+	if loc == ChromaLocationUnspecified {
+		loc = ChromaLocationLeft
+	}
+	xpos, ypos, ret := goChromaLocationEnumToPos(int(loc))
+	if ret < 0 {
+		return 0, 0, ErrInvalidArgument
+	}
+	return xpos, ypos, nil
+}
+
+// ResampleChromaSamples converts a chroma component of a width x height
+// image from the from layout to the to layout. It covers any change between
+// the 4:4:4, 4:2:2, 4:4:0, 4:2:0, 4:1:1 and 4:1:0 subsamplings as well as
+// changes of siting alone, such as left to center. Results are clamped to
+// the codes of the given bit depth, which Catmull-Rom and Lanczos can
+// overshoot.
+//
+// src must have the dimensions of the from layout and the result has those
+// of the to layout. Other sizes, subsampling factors above 4 and unknown
+// locations return ErrInvalidArgument.
+func ResampleChromaSamples(src *ComponentSamples, width, height, depth int,
+	from, to ChromaLayout, filter ChromaFilter) (*ComponentSamples, error) {
+	if src == nil || width < 0 || height < 0 || depth < 1 || depth > 16 {
+		return nil, ErrInvalidArgument
+	}
+	iw, ih := shiftCeil(width, from.Log2ChromaW),
+		shiftCeil(height, from.Log2ChromaH)
+	err := checkComponentSamples(len(src.Samples), src.Width, src.Height,
+		src.Stride, iw, ih)
+	if err != nil {
+		return nil, err
+	}
+	plane := make([]float32, iw*ih)
+	for y := 0; y < ih; y++ {
+		for x := 0; x < iw; x++ {
+			plane[y*iw+x] = float32(src.Samples[y*src.Stride+x])
+		}
+	}
+	plane, err = resampleLayout(plane, width, height, from, to, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	ow, oh := shiftCeil(width, to.Log2ChromaW),
+		shiftCeil(height, to.Log2ChromaH)
+	out := &ComponentSamples{Width: ow, Height: oh, Stride: ow,
+		Samples: make([]uint16, ow*oh)}
+	maxv := float64(int(1)<<depth - 1)
+	for i, v := range plane {
+		out.Samples[i] = uint16(math.Round(math.Max(0,
+			math.Min(float64(v), maxv))))
+	}
+	return out, nil
+}
+
+// ResampleChroma copies src into dst, resampling the chroma from the
+// subsampling of src sited at from to the subsampling of dst sited at to.
+// Luma and alpha are copied unchanged. Both frames must hold integer YUV
+// formats with the same dimensions and component depths, such as yuv420p
+// and yuv444p or the same format for a pure siting change; Convert handles
+// everything else. Other frames return ErrInvalidArgument.
+func ResampleChroma(dst, src *Frame, from, to ChromaLocation,
+	filter ChromaFilter) error {
+	if dst == nil || src == nil || dst == src || dst.width != src.width ||
+		dst.height != src.height || !src.desc.IsYUV() ||
+		!dst.desc.IsYUV() || src.desc.IsFloat() || dst.desc.IsFloat() ||
+		src.desc.IsPaletted() || src.desc.NbComponents() !=
+		dst.desc.NbComponents() || src.desc.NbComponents() < 3 {
+		return ErrInvalidArgument
+	}
+	for c := 0; c < src.desc.NbComponents(); c++ {
+		sc, _ := src.desc.Component(c)
+		dc, _ := dst.desc.Component(c)
+		if sc.Depth != dc.Depth || sc.Depth > 16 {
+			return ErrInvalidArgument
+		}
+	}
+
+	fromLayout := chromaLayoutOf(src.desc, from)
+	toLayout := chromaLayoutOf(dst.desc, to)
+	if _, _, err := fromLayout.positions(); err != nil {
+		return err
+	}
+	if _, _, err := toLayout.positions(); err != nil {
+		return err
+	}
+	for c := 0; c < src.desc.NbComponents(); c++ {
+		s, err := ReadComponent(src, c)
+		if err != nil {
+			return err
+		}
+		if c == 1 || c == 2 {
+			comp, _ := src.desc.Component(c)
+			s, err = ResampleChromaSamples(s, src.width, src.height,
+				comp.Depth, fromLayout, toLayout, filter)
+			if err != nil {
+				return err
+			}
+		}
+		for y := 0; y < s.Height; y++ {
+			writeLine(s.Samples[y*s.Stride:][:s.Width], dst.data,
+				dst.linesize, dst.desc, 0, y, c, true)
+		}
+	}
+	return nil
+}
+
+// resampleChroma converts a chroma plane of a w x h image from 2^fromW by
+// 2^fromH subsampling to 2^toW by 2^toH, keeping the siting given by xpos
+// and ypos. It uses the bilinear filter, which averages with a triangle
+// filter as wide as two chroma samples when reducing.
+func resampleChroma(plane []float32, w, h, fromW, fromH, toW, toH, xpos,
+	ypos int) []float32 {
+	plane = resampleAxis(plane, w, h, true, fromW, toW, xpos, xpos,
+		shiftCeil(h, fromH), ChromaFilterBilinear)
+	return resampleAxis(plane, w, h, false, fromH, toH, ypos, ypos,
+		shiftCeil(w, toW), ChromaFilterBilinear)
+}
+
+// resampleLayout converts a chroma plane of a w x h image between layouts.
+func resampleLayout(plane []float32, w, h int, from, to ChromaLayout,
+	filter ChromaFilter) ([]float32, error) {
+	fx, fy, err := from.positions()
+	if err != nil {
+		return nil, err
+	}
+	tx, ty, err := to.positions()
+	if err != nil {
+		return nil, err
+	}
+	plane = resampleAxis(plane, w, h, true, from.Log2ChromaW, to.Log2ChromaW,
+		fx, tx, shiftCeil(h, from.Log2ChromaH), filter)
+	return resampleAxis(plane, w, h, false, from.Log2ChromaH, to.Log2ChromaH,
+		fy, ty, shiftCeil(w, to.Log2ChromaW), filter), nil
+}
+
+// resampleAxis resamples lines rows of plane when horizontal is set, or
+// lines columns otherwise, for an image of w x h luma samples. The plane is
+// returned unchanged when neither the subsampling nor the siting of the axis
+// changes.
+func resampleAxis(plane []float32, w, h int, horizontal bool, fromShift,
+	toShift, fromPos, toPos, lines int, filter ChromaFilter) []float32 {
+	n := h
+	if horizontal {
+		n = w
+	}
+	inN, outN := shiftCeil(n, fromShift), shiftCeil(n, toShift)
+	fromOff := float64(fromPos*(1<<fromShift-1)) / 256
+	toOff := float64(toPos*(1<<toShift-1)) / 256
+	if fromShift == toShift && fromOff == toOff {
+		return plane
+	}
+
+	out := make([]float32, outN*lines)
+	for l := 0; l < lines; l++ {
+		if horizontal {
+			resampleLine(out[l*outN:], 1, outN, plane[l*inN:], 1, inN,
+				fromShift, toShift, fromOff, toOff, filter)
+		} else {
+			resampleLine(out[l:], lines, outN, plane[l:], lines, inN,
+				fromShift, toShift, fromOff, toOff, filter)
+		}
+	}
+	return out
+}
+
+// resampleLine resamples inN strided samples of src, subsampled by
+// 2^fromShift with the first sample at fromOff in full resolution units,
+// into outN samples of dst subsampled by 2^toShift starting at toOff.
+// Source indexes past the edges are clamped.
+func resampleLine(dst []float32, dstStride, outN int, src []float32,
+	srcStride, inN, fromShift, toShift int, fromOff, toOff float64,
+	filter ChromaFilter) {
+	sIn, sOut := float64(int(1)<<fromShift), float64(int(1)<<toShift)
+	clampIdx := func(i int) int { return min(max(i, 0), inN-1) }
+	kernel, radius := filter.kernel()
+	// Widen the kernel when reducing so it averages every covered sample.
+	scale := math.Max(1, sOut/sIn)
+	radius *= scale
+
+	for j := 0; j < outN; j++ {
+		// Position of output sample j in source sample units.
+		t := (float64(j)*sOut + toOff - fromOff) / sIn
+		if filter == ChromaFilterNearest {
+			dst[j*dstStride] = src[clampIdx(int(math.Floor(t+0.5)))*srcStride]
+			continue
+		}
+		var sum, norm float64
+		for i := int(math.Ceil(t - radius)); i <= int(math.Floor(t+radius)); i++ {
+			wgt := kernel((float64(i) - t) / scale)
+			if wgt == 0 {
+				continue
+			}
+			sum += wgt * float64(src[clampIdx(i)*srcStride])
+			norm += wgt
+		}
+		if norm == 0 {
+			dst[j*dstStride] = src[clampIdx(int(math.Floor(t+0.5)))*srcStride]
+			continue
+		}
+		dst[j*dstStride] = float32(sum / norm)
+	}
+}
@@ -0,0 +1,116 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_ResampleChromaSamples_NearestRoundTrip(t *testing.T) {
+	const w, h = 13, 9
+	full := pixfmts.ChromaLayout{Location: pixfmts.ChromaLocationLeft}
+	rng := rand.New(rand.NewSource(3))
+	for _, sub := range [][2]int{{1, 1}, {1, 0}, {0, 1}, {2, 0}, {2, 1}} {
+		layout := pixfmts.ChromaLayout{Log2ChromaW: sub[0],
+			Log2ChromaH: sub[1], Location: pixfmts.ChromaLocationLeft}
+		cw, ch := (w+1<<sub[0]-1)>>sub[0], (h+1<<sub[1]-1)>>sub[1]
+		src := &pixfmts.ComponentSamples{Width: cw, Height: ch, Stride: cw,
+			Samples: make([]uint16, cw*ch)}
+		for i := range src.Samples {
+			src.Samples[i] = uint16(rng.Intn(256))
+		}
+
+		up, err := pixfmts.ResampleChromaSamples(src, w, h, 8, layout, full,
+			pixfmts.ChromaFilterNearest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		down, err := pixfmts.ResampleChromaSamples(up, w, h, 8, full, layout,
+			pixfmts.ChromaFilterNearest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(down.Samples, src.Samples) {
+			t.Fatalf("4:%v round trip changed the samples", sub)
+		}
+	}
+}
+
+func Test_ResampleChromaSamples_Siting(t *testing.T) {
+	left := pixfmts.ChromaLayout{Log2ChromaW: 1,
+		Location: pixfmts.ChromaLocationLeft}
+	center := pixfmts.ChromaLayout{Log2ChromaW: 1,
+		Location: pixfmts.ChromaLocationCenter}
+	src := &pixfmts.ComponentSamples{Width: 2, Height: 1, Stride: 2,
+		Samples: []uint16{0, 100}}
+
+	// Center sited samples sit a quarter of the way to the next left sited
+	// one.
+	got, err := pixfmts.ResampleChromaSamples(src, 4, 1, 8, left, center,
+		pixfmts.ChromaFilterBilinear)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.Samples, []uint16{25, 100}) {
+		t.Fatalf("unexpected center sited samples: %v", got.Samples)
+	}
+
+	for _, filter := range []pixfmts.ChromaFilter{
+		pixfmts.ChromaFilterCatmullRom, pixfmts.ChromaFilterLanczos} {
+		flat := &pixfmts.ComponentSamples{Width: 2, Height: 1, Stride: 2,
+			Samples: []uint16{77, 77}}
+		got, err := pixfmts.ResampleChromaSamples(flat, 4, 1, 8, left,
+			center, filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got.Samples, flat.Samples) {
+			t.Fatalf("filter %d changed a flat plane: %v", filter,
+				got.Samples)
+		}
+	}
+}
+
+func Test_ResampleChroma(t *testing.T) {
+	src := newFrame(t, pixfmts.PixFmtYUV420P, 6, 4)
+	planes := src.Planes()
+	rand.New(rand.NewSource(4)).Read(planes[0])
+	for i := range planes[1] {
+		planes[1][i], planes[2][i] = 90, 160
+	}
+
+	dst := newFrame(t, pixfmts.PixFmtYUV444P, 6, 4)
+	if err := pixfmts.ResampleChroma(dst, src, pixfmts.ChromaLocationLeft,
+		pixfmts.ChromaLocationCenter, pixfmts.ChromaFilterLanczos); err != nil {
+		t.Fatal(err)
+	}
+	for c, want := range []uint16{0, 90, 160} {
+		got, err := pixfmts.ReadComponent(dst, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c == 0 {
+			luma, _ := pixfmts.ReadComponent(src, 0)
+			if !slices.Equal(got.Samples, luma.Samples) {
+				t.Fatal("luma changed")
+			}
+			continue
+		}
+		for _, v := range got.Samples {
+			if v != want {
+				t.Fatalf("component %d: got %d, want %d", c, v, want)
+			}
+		}
+	}
+
+	deep := newFrame(t, pixfmts.PixFmtYUV444P10LE, 6, 4)
+	if err := pixfmts.ResampleChroma(deep, src, pixfmts.ChromaLocationLeft,
+		pixfmts.ChromaLocationLeft,
+		pixfmts.ChromaFilterBilinear); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package gopixfmts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pixFmtNoneName is the name FFmpeg tools print for PixFmtNone.
+const pixFmtNoneName = "none"
+
+// String returns the canonical FFmpeg name of the pixel format, such as
+// "yuv420p10le". PixFmtNone is reported as "none" and unknown values as
+// "PixelFormat(n)".
+func (pf PixelFormat) String() string {
+	if pf == PixFmtNone {
+		return pixFmtNoneName
+	}
+	if name := GetPixFmtName(pf); name != "" {
+		return name
+	}
+	return fmt.Sprintf("PixelFormat(%d)", int(pf))
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical FFmpeg
+// name of the pixel format. Unknown values return an error wrapping
+// ErrUnknownPixelFormat.
+func (pf PixelFormat) MarshalText() ([]byte, error) {
+	if pf == PixFmtNone {
+		return []byte(pixFmtNoneName), nil
+	}
+	name := GetPixFmtName(pf)
+	if name == "" {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownPixelFormat, int(pf))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by looking the name up
+// with GetPixFmt. "none" decodes to PixFmtNone.
+func (pf *PixelFormat) UnmarshalText(text []byte) error {
+	if string(text) == pixFmtNoneName {
+		*pf = PixFmtNone
+		return nil
+	}
+	v, err := GetPixFmt(string(text))
+	if err != nil {
+		return err
+	}
+	*pf = v
+	return nil
+}
+
+// String returns the name of the color primaries, such as "bt709", or
+// "ColorPrimaries(n)" for unknown values.
+func (p ColorPrimaries) String() string {
+	if name := ColorPrimariesName(int(p)); name != "" {
+		return name
+	}
+	return fmt.Sprintf("ColorPrimaries(%d)", int(p))
+}
+
+// MarshalText implements encoding.TextMarshaler using ColorPrimariesName.
+func (p ColorPrimaries) MarshalText() ([]byte, error) {
+	return marshalName(ColorPrimariesName(int(p)), "color primaries", int(p))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be a
+// name, ignoring case, returned by ColorPrimariesName.
+func (p *ColorPrimaries) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, "color primaries", int(ColorPrimariesNB),
+		ColorPrimariesName)
+	if err != nil {
+		return err
+	}
+	*p = ColorPrimaries(v)
+	return nil
+}
+
+// String returns the name of the transfer characteristic, such as
+// "smpte2084", or "ColorTransferCharacteristic(n)" for unknown values.
+func (t ColorTransferCharacteristic) String() string {
+	if name := ColorTransferName(int(t)); name != "" {
+		return name
+	}
+	return fmt.Sprintf("ColorTransferCharacteristic(%d)", int(t))
+}
+
+// MarshalText implements encoding.TextMarshaler using ColorTransferName.
+func (t ColorTransferCharacteristic) MarshalText() ([]byte, error) {
+	return marshalName(ColorTransferName(int(t)), "color transfer", int(t))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be a
+// name, ignoring case, returned by ColorTransferName.
+func (t *ColorTransferCharacteristic) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, "color transfer", int(ColorTransferCharacteristicNB),
+		ColorTransferName)
+	if err != nil {
+		return err
+	}
+	*t = ColorTransferCharacteristic(v)
+	return nil
+}
+
+// String returns the name of the color space, such as "bt2020nc", or
+// "ColorSpace(n)" for unknown values.
+func (s ColorSpace) String() string {
+	if name := ColorSpaceName(int(s)); name != "" {
+		return name
+	}
+	return fmt.Sprintf("ColorSpace(%d)", int(s))
+}
+
+// MarshalText implements encoding.TextMarshaler using ColorSpaceName.
+func (s ColorSpace) MarshalText() ([]byte, error) {
+	return marshalName(ColorSpaceName(int(s)), "color space", int(s))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be a
+// name, ignoring case, returned by ColorSpaceName.
+func (s *ColorSpace) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, "color space", int(ColorSpaceNB),
+		ColorSpaceName)
+	if err != nil {
+		return err
+	}
+	*s = ColorSpace(v)
+	return nil
+}
+
+// String returns the name of the color range, such as "tv" or "pc", or
+// "ColorRange(n)" for unknown values.
+func (r ColorRange) String() string {
+	if name := ColorRangeName(int(r)); name != "" {
+		return name
+	}
+	return fmt.Sprintf("ColorRange(%d)", int(r))
+}
+
+// MarshalText implements encoding.TextMarshaler using ColorRangeName.
+func (r ColorRange) MarshalText() ([]byte, error) {
+	return marshalName(ColorRangeName(int(r)), "color range", int(r))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be a
+// name, ignoring case, returned by ColorRangeName.
+func (r *ColorRange) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, "color range", colorRangeCount(),
+		ColorRangeName)
+	if err != nil {
+		return err
+	}
+	*r = ColorRange(v)
+	return nil
+}
+
+// String returns the name of the chroma location, such as "left" or
+// "topleft", or "ChromaLocation(n)" for unknown values.
+func (l ChromaLocation) String() string {
+	if name := ChromaLocationName(int(l)); name != "" {
+		return name
+	}
+	return fmt.Sprintf("ChromaLocation(%d)", int(l))
+}
+
+// MarshalText implements encoding.TextMarshaler using ChromaLocationName.
+func (l ChromaLocation) MarshalText() ([]byte, error) {
+	return marshalName(ChromaLocationName(int(l)), "chroma location", int(l))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be a
+// name, ignoring case, returned by ChromaLocationName.
+func (l *ChromaLocation) UnmarshalText(text []byte) error {
+	v, err := unmarshalName(text, "chroma location", int(ChromaLocationNB),
+		ChromaLocationName)
+	if err != nil {
+		return err
+	}
+	*l = ChromaLocation(v)
+	return nil
+}
+
+// helper: turn a looked up name into MarshalText output
+func marshalName(name, kind string, v int) ([]byte, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: unknown %s: %d", ErrInvalidArgument, kind, v)
+	}
+	return []byte(name), nil
+}
+
+// unmarshalName looks text up with valueFromName among the names nameOf
+// returns for [0, n).
+func unmarshalName(text []byte, kind string, n int,
+	nameOf func(int) string) (int, error) {
+	if len(text) == 0 {
+		return -1, fmt.Errorf("%w: empty %s name", ErrInvalidArgument, kind)
+	}
+	v, ok := valueFromName(string(text), n, nameOf)
+	if !ok {
+		return -1, fmt.Errorf("%w: %s %q", ErrUnknownColorName, kind, text)
+	}
+	return v, nil
+}
+
+// valueFromName returns the value in [0, n) whose name is name, ignoring
+// case. Unlike the *FromName lookups it does not match prefixes, so
+// "ycgco-re" is not read as "ycgco" and every name decodes to the value it
+// was written from.
+func valueFromName(name string, n int, nameOf func(int) string) (int, bool) {
+	for v := 0; v < n; v++ {
+		if nv := nameOf(v); nv != "" && strings.EqualFold(nv, name) {
+			return v, true
+		}
+	}
+	return -1, false
+}
+
+// colorRangeCount returns the number of named ColorRange values, which
+// libavutil does not export as a constant.
+func colorRangeCount() int {
+	n := 0
+	for ColorRangeName(n) != "" {
+		n++
+	}
+	return n
+}
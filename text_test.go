@@ -0,0 +1,114 @@
+package gopixfmts_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_PixelFormat_String(t *testing.T) {
+	if s := pixfmts.PixFmtYUV420P10LE.String(); s != "yuv420p10le" {
+		t.Fatalf("unexpected name: %q", s)
+	}
+	if s := pixfmts.PixFmtNone.String(); s != "none" {
+		t.Fatalf("unexpected name: %q", s)
+	}
+}
+
+func Test_JSON_RoundTrip(t *testing.T) {
+	type config struct {
+		PixFmt    pixfmts.PixelFormat                 `json:"pix_fmt"`
+		Primaries pixfmts.ColorPrimaries              `json:"primaries"`
+		Transfer  pixfmts.ColorTransferCharacteristic `json:"transfer"`
+		Space     pixfmts.ColorSpace                  `json:"space"`
+		Range     pixfmts.ColorRange                  `json:"range"`
+		ChromaLoc pixfmts.ChromaLocation              `json:"chroma_loc"`
+	}
+
+	var cfg config
+	in := `{"pix_fmt":"yuv420p10le","primaries":"bt2020",` +
+		`"transfer":"smpte2084","space":"bt2020nc","range":"tv",` +
+		`"chroma_loc":"topleft"}`
+	if err := json.Unmarshal([]byte(in), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	want := config{
+		PixFmt:    pixfmts.PixFmtYUV420P10LE,
+		Primaries: pixfmts.ColorPrimariesBT2020,
+		Transfer:  pixfmts.ColorTransferCharacteristicSMPTE2084,
+		Space:     pixfmts.ColorSpaceBT2020_NCL,
+		Range:     pixfmts.ColorRangeMPEG,
+		ChromaLoc: pixfmts.ChromaLocationTopLeft,
+	}
+	if cfg != want {
+		t.Fatalf("got %+v want %+v", cfg, want)
+	}
+
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Fatalf("got %s want %s", out, in)
+	}
+
+	if err := json.Unmarshal([]byte(`{"pix_fmt":"nope"}`), &cfg); err == nil {
+		t.Fatal("expected error for unknown pixel format")
+	}
+}
+
+// textRoundTrip marshals v and checks that unmarshaling the text into a
+// fresh T gives v back.
+func textRoundTrip[T interface {
+	comparable
+	MarshalText() ([]byte, error)
+}, PT interface {
+	*T
+	UnmarshalText([]byte) error
+}](t *testing.T, v T) {
+	t.Helper()
+	text, err := v.MarshalText()
+	if err != nil {
+		t.Fatalf("%v: %v", v, err)
+	}
+	var got T
+	if err := PT(&got).UnmarshalText(text); err != nil {
+		t.Fatalf("%q: %v", text, err)
+	}
+	if got != v {
+		t.Fatalf("%q decoded to %v, want %v", text, got, v)
+	}
+}
+
+func Test_Text_RoundTripAll(t *testing.T) {
+	for pf := range pixfmts.AllPixelFormats() {
+		textRoundTrip(t, pf)
+	}
+	textRoundTrip(t, pixfmts.PixFmtNone)
+	for p := range pixfmts.AllColorPrimaries() {
+		textRoundTrip(t, p)
+	}
+	for tc := range pixfmts.AllColorTransferCharacteristics() {
+		textRoundTrip(t, tc)
+	}
+	for s := range pixfmts.AllColorSpaces() {
+		textRoundTrip(t, s)
+	}
+	for r := range pixfmts.AllColorRanges() {
+		textRoundTrip(t, r)
+	}
+	for l := range pixfmts.AllChromaLocations() {
+		textRoundTrip(t, l)
+	}
+}
+
+func Test_Text_RejectsPrefix(t *testing.T) {
+	var s pixfmts.ColorSpace
+	if err := s.UnmarshalText([]byte("ycgco-rex")); err == nil {
+		t.Fatalf("expected error, got %v", s)
+	}
+	if err := s.UnmarshalText([]byte("")); err == nil {
+		t.Fatal("expected error for empty name")
+	}
+}
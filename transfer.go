@@ -0,0 +1,289 @@
+package gopixfmts
+
+import "math"
+
+// TransferFunction maps normalized values of one side of a transfer
+// characteristic to the other.
+type TransferFunction func(float64) float64
+
+// Float32 applies fn to a float32 value.
+func (fn TransferFunction) Float32(v float32) float32 {
+	return float32(fn(float64(v)))
+}
+
+// Sample applies fn to a normalized integer sample of the given bit depth,
+// as stored in full range formats, clamping the result to the codes of the
+// depth. Depths outside of 1..32 return v unchanged.
+func (fn TransferFunction) Sample(v uint32, depth int) uint32 {
+	if depth < 1 || depth > 32 {
+		return v
+	}
+	maxv := float64(uint64(1)<<depth - 1)
+	out := fn(float64(v) / maxv)
+	if !(out > 0) {
+		return 0
+	}
+	return uint32(math.Round(math.Min(out, 1) * maxv))
+}
+
+// LinearToSignal returns the function converting linear light to the
+// non-linear signal of trc, which is its OETF or inverse EOTF.
+//
+// The curves are those of av_csp_trc_func_from_id, with two intentional
+// differences. Linear light is normalized so 1 is the reference white,
+// except for SMPTE ST 2084 (PQ), where 1 is 10000 cd/m² so the whole PQ
+// range maps to [0, 1]; FFmpeg scales PQ input differently. The negative
+// branch of BT.1361 follows the specification rather than FFmpeg, which
+// has the opposite sign on its offset term. BT.601, BT.709 and BT.2020
+// share one curve. Unspecified and reserved values return
+// ErrInvalidArgument.
+func LinearToSignal(trc ColorTransferCharacteristic) (TransferFunction,
+	error) {
+	if trc < 0 || int(trc) >= len(transferFunctions) ||
+		transferFunctions[trc][0] == nil {
+		return nil, ErrInvalidArgument
+	}
+	return transferFunctions[trc][0], nil
+}
+
+// SignalToLinear returns the inverse of LinearToSignal. It matches
+// av_csp_itrc_func_from_id with the same two differences.
+func SignalToLinear(trc ColorTransferCharacteristic) (TransferFunction,
+	error) {
+	if trc < 0 || int(trc) >= len(transferFunctions) ||
+		transferFunctions[trc][1] == nil {
+		return nil, ErrInvalidArgument
+	}
+	return transferFunctions[trc][1], nil
+}
+
+// transferFunctions holds the forward and inverse functions of every
+// transfer characteristic.
+var transferFunctions = [ColorTransferCharacteristicNB][2]TransferFunction{
+	ColorTransferCharacteristicBT709:        {trcBT709, itrcBT709},
+	ColorTransferCharacteristicGamma22:      {trcGamma(2.2), itrcGamma(2.2)},
+	ColorTransferCharacteristicGamma28:      {trcGamma(2.8), itrcGamma(2.8)},
+	ColorTransferCharacteristicSMPTE170M:    {trcBT709, itrcBT709},
+	ColorTransferCharacteristicSMPTE240M:    {trcSMPTE240M, itrcSMPTE240M},
+	ColorTransferCharacteristicLinear:       {trcLinear, trcLinear},
+	ColorTransferCharacteristicLog:          {trcLog, itrcLog},
+	ColorTransferCharacteristicLogSqrt:      {trcLogSqrt, itrcLogSqrt},
+	ColorTransferCharacteristicIEC61966_2_4: {trcIEC61966_2_4, itrcIEC61966_2_4},
+	ColorTransferCharacteristicBT1361_ECG:   {trcBT1361, itrcBT1361},
+	ColorTransferCharacteristicIEC61966_2_1: {trcSRGB, itrcSRGB},
+	ColorTransferCharacteristicBT2020_10:    {trcBT709, itrcBT709},
+	ColorTransferCharacteristicBT2020_12:    {trcBT709, itrcBT709},
+	ColorTransferCharacteristicSMPTE2084:    {trcPQ, itrcPQ},
+	ColorTransferCharacteristicSMPTE428:     {trcSMPTE428, itrcSMPTE428},
+	ColorTransferCharacteristicARIB_STD_B67: {trcHLG, itrcHLG},
+}
+
+// ---------------- Curves ----------------
+
+const (
+	bt709Alpha = 1.099296826809442
+	bt709Beta  = 0.018053968510807
+
+	pqC1 = 3424.0 / 4096.0
+	pqC2 = 32.0 * 2413.0 / 4096.0
+	pqC3 = 32.0 * 2392.0 / 4096.0
+	pqM  = 128.0 * 2523.0 / 4096.0
+	pqN  = 0.25 * 2610.0 / 4096.0
+
+	hlgA = 0.17883277
+	hlgB = 0.28466892
+	hlgC = 0.55991073
+)
+
+func trcBT709(l float64) float64 {
+	switch {
+	case l < 0:
+		return 0
+	case l < bt709Beta:
+		return 4.5 * l
+	}
+	return bt709Alpha*math.Pow(l, 0.45) - (bt709Alpha - 1)
+}
+
+func itrcBT709(e float64) float64 {
+	switch {
+	case e < 0:
+		return 0
+	case e < 4.5*bt709Beta:
+		return e / 4.5
+	}
+	return math.Pow((e+(bt709Alpha-1))/bt709Alpha, 1/0.45)
+}
+
+func trcGamma(g float64) TransferFunction {
+	return func(l float64) float64 { return math.Pow(math.Max(l, 0), 1/g) }
+}
+
+func itrcGamma(g float64) TransferFunction {
+	return func(e float64) float64 { return math.Pow(math.Max(e, 0), g) }
+}
+
+func trcSMPTE240M(l float64) float64 {
+	const a, b = 1.1115, 0.0228
+	switch {
+	case l < 0:
+		return 0
+	case l < b:
+		return 4 * l
+	}
+	return a*math.Pow(l, 0.45) - (a - 1)
+}
+
+func itrcSMPTE240M(e float64) float64 {
+	const a, b = 1.1115, 0.0228
+	switch {
+	case e < 0:
+		return 0
+	case e < 4*b:
+		return e / 4
+	}
+	return math.Pow((e+(a-1))/a, 1/0.45)
+}
+
+func trcLinear(v float64) float64 { return v }
+
+func trcLog(l float64) float64 {
+	if l > 0.01 {
+		return 1 + math.Log10(l)/2
+	}
+	return 0
+}
+
+// itrcLog clamps negative signals to the 0.01 floor of the curve, like
+// av_csp_itrc_func_from_id.
+func itrcLog(e float64) float64 {
+	if e < 0 {
+		return 0.01
+	}
+	return math.Pow(10, 2*(e-1))
+}
+
+func trcLogSqrt(l float64) float64 {
+	if l > math.Sqrt(10)/1000 {
+		return 1 + math.Log10(l)/2.5
+	}
+	return 0
+}
+
+// itrcLogSqrt clamps negative signals to the sqrt(10)/1000 floor of the
+// curve, like av_csp_itrc_func_from_id.
+func itrcLogSqrt(e float64) float64 {
+	if e < 0 {
+		return math.Sqrt(10) / 1000
+	}
+	return math.Pow(10, 2.5*(e-1))
+}
+
+func trcIEC61966_2_4(l float64) float64 {
+	if l <= -bt709Beta {
+		return -bt709Alpha*math.Pow(-l, 0.45) + (bt709Alpha - 1)
+	}
+	if l < bt709Beta {
+		return 4.5 * l
+	}
+	return bt709Alpha*math.Pow(l, 0.45) - (bt709Alpha - 1)
+}
+
+func itrcIEC61966_2_4(e float64) float64 {
+	if e <= -4.5*bt709Beta {
+		return -math.Pow((-e+(bt709Alpha-1))/bt709Alpha, 1/0.45)
+	}
+	if e < 4.5*bt709Beta {
+		return e / 4.5
+	}
+	return math.Pow((e+(bt709Alpha-1))/bt709Alpha, 1/0.45)
+}
+
+// trcBT1361 and itrcBT1361 subtract the (a - 1) term in the negative branch
+// as written in ITU-R BT.1361. av_csp_trc_func_from_id adds it instead, so
+// values below -0.0045 differ from FFmpeg's.
+func trcBT1361(l float64) float64 {
+	switch {
+	case l <= -0.0045:
+		return -(bt709Alpha*math.Pow(-4*l, 0.45) - (bt709Alpha - 1)) / 4
+	case l < bt709Beta:
+		return 4.5 * l
+	}
+	return bt709Alpha*math.Pow(l, 0.45) - (bt709Alpha - 1)
+}
+
+func itrcBT1361(e float64) float64 {
+	switch {
+	case e <= -0.02025:
+		return -math.Pow((-4*e+(bt709Alpha-1))/bt709Alpha, 1/0.45) / 4
+	case e < 4.5*bt709Beta:
+		return e / 4.5
+	}
+	return math.Pow((e+(bt709Alpha-1))/bt709Alpha, 1/0.45)
+}
+
+func trcSRGB(l float64) float64 {
+	const a, b = 1.055, 0.0031308
+	switch {
+	case l < 0:
+		return 0
+	case l < b:
+		return 12.92 * l
+	}
+	return a*math.Pow(l, 1/2.4) - (a - 1)
+}
+
+func itrcSRGB(e float64) float64 {
+	const a, b = 1.055, 0.0031308
+	switch {
+	case e < 0:
+		return 0
+	case e < 12.92*b:
+		return e / 12.92
+	}
+	return math.Pow((e+(a-1))/a, 2.4)
+}
+
+func trcPQ(l float64) float64 {
+	if l <= 0 {
+		return 0
+	}
+	ln := math.Pow(l, pqN)
+	return math.Pow((pqC1+pqC2*ln)/(1+pqC3*ln), pqM)
+}
+
+func itrcPQ(e float64) float64 {
+	if e <= 0 {
+		return 0
+	}
+	ep := math.Pow(e, 1/pqM)
+	return math.Pow(math.Max(ep-pqC1, 0)/(pqC2-pqC3*ep), 1/pqN)
+}
+
+func trcSMPTE428(l float64) float64 {
+	return math.Pow(math.Max(l, 0)*48/52.37, 1/2.6)
+}
+
+func itrcSMPTE428(e float64) float64 {
+	return math.Pow(math.Max(e, 0), 2.6) * 52.37 / 48
+}
+
+func trcHLG(l float64) float64 {
+	switch {
+	case l < 0:
+		return 0
+	case l <= 1.0/12:
+		return math.Sqrt(3 * l)
+	}
+	return hlgA*math.Log(12*l-hlgB) + hlgC
+}
+
+func itrcHLG(e float64) float64 {
+	switch {
+	case e < 0:
+		return 0
+	case e <= 0.5:
+		return e * e / 3
+	}
+	return (math.Exp((e-hlgC)/hlgA) + hlgB) / 12
+}
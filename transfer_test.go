@@ -0,0 +1,89 @@
+package gopixfmts_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_TransferFunctions_RoundTrip(t *testing.T) {
+	for trc := range pixfmts.AllColorTransferCharacteristics() {
+		fwd, err := pixfmts.LinearToSignal(trc)
+		if err != nil {
+			continue
+		}
+		inv, err := pixfmts.SignalToLinear(trc)
+		if err != nil {
+			t.Fatalf("%v has no inverse: %v", trc, err)
+		}
+		for _, l := range []float64{0.02, 0.1, 0.5, 1} {
+			if got := inv(fwd(l)); math.Abs(got-l) > 1e-9 {
+				t.Fatalf("%v: %v round trips to %v", trc, l, got)
+			}
+		}
+	}
+}
+
+func Test_TransferFunctions_KnownValues(t *testing.T) {
+	srgb, err := pixfmts.LinearToSignal(
+		pixfmts.ColorTransferCharacteristicIEC61966_2_1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := srgb.Sample(0x8000, 16); got != 0xbc40 {
+		t.Fatalf("sRGB of linear 0.5 is %#x", got)
+	}
+
+	// 100 cd/m² is PQ code 520 of 1023.
+	pq, err := pixfmts.LinearToSignal(
+		pixfmts.ColorTransferCharacteristicSMPTE2084)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := math.Round(pq(0.01) * 1023); got != 520 {
+		t.Fatalf("PQ of 100 cd/m² is code %v", got)
+	}
+
+	hlg, err := pixfmts.SignalToLinear(
+		pixfmts.ColorTransferCharacteristicARIB_STD_B67)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hlg.Float32(0.5); math.Abs(float64(got)-1.0/12) > 1e-7 {
+		t.Fatalf("HLG signal 0.5 is %v", got)
+	}
+
+	// BT.1361 subtracts the offset term below -0.0045, as the
+	// specification does and FFmpeg does not.
+	bt1361, err := pixfmts.LinearToSignal(
+		pixfmts.ColorTransferCharacteristicBT1361_ECG)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := -(1.099296826809442*math.Pow(0.04, 0.45) - 0.099296826809442) / 4
+	if got := bt1361(-0.01); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("BT.1361 of linear -0.01 is %v, want %v", got, want)
+	}
+
+	// Negative signals clamp to the floor of the logarithmic curves.
+	for trc, floor := range map[pixfmts.ColorTransferCharacteristic]float64{
+		pixfmts.ColorTransferCharacteristicLog:     0.01,
+		pixfmts.ColorTransferCharacteristicLogSqrt: math.Sqrt(10) / 1000,
+	} {
+		fn, err := pixfmts.SignalToLinear(trc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fn(-0.5); got != floor {
+			t.Fatalf("%v of signal -0.5 is %v, want %v", trc, got, floor)
+		}
+	}
+
+	if _, err := pixfmts.LinearToSignal(
+		pixfmts.ColorTransferCharacteristicUnspecified); !errors.Is(err,
+		pixfmts.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package gopixfmts
+
+import "errors"
+
+// PixFmtFlag is a bitset of AV_PIX_FMT_FLAG_* values describing a pixel
+// format.
+type PixFmtFlag uint64
+
+// ComponentDescriptor is a Descriptor of one of the 4 planes within a
+// PixFmtDescRef.
+type ComponentDescriptor struct {
+	Plane  int
+	Step   int
+	Offset int
+	Shift  int
+	Depth  int
+}
+
+var (
+	ErrUnknownPixelFormat = errors.New("unknown pixel format")
+	ErrInvalidArgument    = errors.New("invalid argument")
+
+	// ErrUnknownColorName is returned by the *FromName functions when
+	// libavutil does not recognize the name.
+	ErrUnknownColorName = errors.New("unknown color name")
+	// ErrNoEndianSwap is returned by PixFmtSwapEndianness for formats
+	// without an opposite endianness counterpart.
+	ErrNoEndianSwap = errors.New("no swapped-endianness pixel format")
+	// ErrNoSuitableFormat is returned when no candidate pixel format can
+	// take the source.
+	ErrNoSuitableFormat = errors.New("no suitable pixel format")
+)
+
+const (
+	FF_LOSS_RESOLUTION        = 0x0001
+	FF_LOSS_DEPTH             = 0x0002
+	FF_LOSS_COLORSPACE        = 0x0004
+	FF_LOSS_ALPHA             = 0x0008
+	FF_LOSS_COLORQUANT        = 0x0010
+	FF_LOSS_CHROMA            = 0x0020
+	FF_LOSS_EXCESS_RESOLUTION = 0x0040
+	FF_LOSS_EXCESS_DEPTH      = 0x0080
+)
@@ -0,0 +1,391 @@
+package gopixfmts
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidY4M is returned, wrapped with the reason, when a YUV4MPEG2
+// stream is malformed or uses a colorspace tag this package does not map.
+var ErrInvalidY4M = errors.New("invalid y4m stream")
+
+// y4mMagic starts every YUV4MPEG2 stream header.
+const y4mMagic = "YUV4MPEG2"
+
+// y4mFrameMagic starts every frame header.
+const y4mFrameMagic = "FRAME"
+
+// ---------------- Colorspace tags ----------------
+
+// y4mFormats maps the C tags of a YUV4MPEG2 header to pixel formats. The
+// 4:2:0 tags also carry the chroma siting. When writing, the first entry
+// matching the format, and for 4:2:0 the chroma location, is used, so the
+// read only "420" alias comes after "420jpeg". xyscss is the XYSCSS value
+// FFmpeg writes alongside the tag, if any.
+var y4mFormats = []struct {
+	tag    string
+	xyscss string
+	format PixelFormat
+	loc    ChromaLocation
+}{
+	{"420jpeg", "420JPEG", PixFmtYUV420P, ChromaLocationCenter},
+	{"420mpeg2", "420MPEG2", PixFmtYUV420P, ChromaLocationLeft},
+	{"420paldv", "420PALDV", PixFmtYUV420P, ChromaLocationTopLeft},
+	{"420", "420JPEG", PixFmtYUV420P, ChromaLocationCenter},
+	{"411", "411", PixFmtYUV411P, ChromaLocationUnspecified},
+	{"422", "422", PixFmtYUV422P, ChromaLocationUnspecified},
+	{"444", "444", PixFmtYUV444P, ChromaLocationUnspecified},
+	{"444alpha", "444", PixFmtYUVA444P, ChromaLocationUnspecified},
+	{"mono", "", PixFmtGray8, ChromaLocationUnspecified},
+	{"mono9", "", PixFmtGRAY9LE, ChromaLocationUnspecified},
+	{"mono10", "", PixFmtGRAY10LE, ChromaLocationUnspecified},
+	{"mono12", "", PixFmtGRAY12LE, ChromaLocationUnspecified},
+	{"mono16", "", PixFmtGray16LE, ChromaLocationUnspecified},
+	{"420p9", "420P9", PixFmtYUV420P9LE, ChromaLocationUnspecified},
+	{"422p9", "422P9", PixFmtYUV422P9LE, ChromaLocationUnspecified},
+	{"444p9", "444P9", PixFmtYUV444P9LE, ChromaLocationUnspecified},
+	{"420p10", "420P10", PixFmtYUV420P10LE, ChromaLocationUnspecified},
+	{"422p10", "422P10", PixFmtYUV422P10LE, ChromaLocationUnspecified},
+	{"444p10", "444P10", PixFmtYUV444P10LE, ChromaLocationUnspecified},
+	{"420p12", "420P12", PixFmtYUV420P12LE, ChromaLocationUnspecified},
+	{"422p12", "422P12", PixFmtYUV422P12LE, ChromaLocationUnspecified},
+	{"444p12", "444P12", PixFmtYUV444P12LE, ChromaLocationUnspecified},
+	{"420p14", "420P14", PixFmtYUV420P14LE, ChromaLocationUnspecified},
+	{"422p14", "422P14", PixFmtYUV422P14LE, ChromaLocationUnspecified},
+	{"444p14", "444P14", PixFmtYUV444P14LE, ChromaLocationUnspecified},
+	{"420p16", "420P16", PixFmtYUV420P16LE, ChromaLocationUnspecified},
+	{"422p16", "422P16", PixFmtYUV422P16LE, ChromaLocationUnspecified},
+	{"444p16", "444P16", PixFmYUV444P16LE, ChromaLocationUnspecified},
+}
+
+// y4mFullRange maps the deprecated full range formats to the format Y4M
+// stores them as, with XCOLORRANGE=FULL.
+var y4mFullRange = map[PixelFormat]PixelFormat{
+	PixFmtYUVJ420P: PixFmtYUV420P,
+	PixFmtYUVJ422:  PixFmtYUV422P,
+	PixFmtYUVJ444P: PixFmtYUV444P,
+}
+
+// y4mTag returns the C tag and XYSCSS value of the given format and chroma
+// location. Only yuv420p distinguishes chroma locations; an unspecified one
+// is written as 420jpeg like FFmpeg does.
+func y4mTag(pf PixelFormat, loc ChromaLocation) (tag, xyscss string,
+	err error) {
+	for _, f := range y4mFormats {
+		if f.format != pf {
+			continue
+		}
+		if pf != PixFmtYUV420P || f.loc == loc ||
+			loc == ChromaLocationUnspecified {
+			return f.tag, f.xyscss, nil
+		}
+	}
+	if pf == PixFmtYUV420P {
+		return "", "", fmt.Errorf("%w: y4m cannot express %s chroma siting",
+			ErrInvalidArgument, ChromaLocationName(int(loc)))
+	}
+	return "", "", fmt.Errorf("%w: y4m cannot express %s",
+		ErrInvalidArgument, GetPixFmtName(pf))
+}
+
+// ---------------- Header ----------------
+
+// Y4MHeader holds the stream parameters of a YUV4MPEG2 stream.
+type Y4MHeader struct {
+	Format PixelFormat
+	Width  int
+	Height int
+
+	// FrameRateNum/FrameRateDen is the frame rate. Streams without one
+	// read as 25:1.
+	FrameRateNum, FrameRateDen int
+
+	// AspectNum:AspectDen is the sample aspect ratio, 0:0 when unknown.
+	AspectNum, AspectDen int
+
+	// Interlacing is 'p' for progressive, 't' for top field first, 'b' for
+	// bottom field first, 'm' for mixed or '?' when unknown.
+	Interlacing byte
+
+	// Range comes from the XCOLORRANGE tag.
+	Range ColorRange
+
+	// ChromaLocation is only expressed for yuv420p, by its C tag.
+	ChromaLocation ChromaLocation
+}
+
+// Colorimetry returns the parts of the stream's colorimetry a Y4M header
+// carries, leaving the others unspecified.
+func (h *Y4MHeader) Colorimetry() Colorimetry {
+	return Colorimetry{
+		Primaries:      ColorPrimariesUnspecified,
+		Transfer:       ColorTransferCharacteristicUnspecified,
+		Space:          ColorSpaceUnspecified,
+		Range:          h.Range,
+		ChromaLocation: h.ChromaLocation,
+	}
+}
+
+// parseY4MHeader parses a stream header line without its trailing newline.
+func parseY4MHeader(line string) (Y4MHeader, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || fields[0] != y4mMagic {
+		return Y4MHeader{}, fmt.Errorf("%w: missing %s magic", ErrInvalidY4M,
+			y4mMagic)
+	}
+	h := Y4MHeader{Format: PixFmtNone, FrameRateNum: 25, FrameRateDen: 1,
+		Interlacing: '?'}
+	tag := ""
+	for _, f := range fields[1:] {
+		var err error
+		v := f[1:]
+		switch f[0] {
+		case 'W':
+			h.Width, err = strconv.Atoi(v)
+		case 'H':
+			h.Height, err = strconv.Atoi(v)
+		case 'C':
+			tag = v
+		case 'I':
+			if len(v) != 1 || !strings.Contains("ptbm?", v) {
+				err = ErrInvalidY4M
+			} else {
+				h.Interlacing = v[0]
+			}
+		case 'F':
+			h.FrameRateNum, h.FrameRateDen, err = parseY4MRatio(v)
+		case 'A':
+			h.AspectNum, h.AspectDen, err = parseY4MRatio(v)
+		case 'X':
+			switch v {
+			case "COLORRANGE=FULL":
+				h.Range = ColorRangeJPEG
+			case "COLORRANGE=LIMITED":
+				h.Range = ColorRangeMPEG
+			}
+		}
+		if err != nil {
+			return Y4MHeader{}, fmt.Errorf("%w: bad header field %q",
+				ErrInvalidY4M, f)
+		}
+	}
+	if checkImageSize(h.Width, h.Height) != nil {
+		return Y4MHeader{}, fmt.Errorf("%w: size %dx%d", ErrInvalidY4M,
+			h.Width, h.Height)
+	}
+	if h.FrameRateNum <= 0 || h.FrameRateDen <= 0 {
+		h.FrameRateNum, h.FrameRateDen = 25, 1
+	}
+
+	// Streams without a C tag are 4:2:0 with JPEG siting.
+	if tag == "" {
+		tag = "420jpeg"
+	}
+	for _, f := range y4mFormats {
+		if f.tag == tag {
+			h.Format, h.ChromaLocation = f.format, f.loc
+			return h, nil
+		}
+	}
+	return Y4MHeader{}, fmt.Errorf("%w: unsupported colorspace %q",
+		ErrInvalidY4M, tag)
+}
+
+func parseY4MRatio(s string) (num, den int, err error) {
+	n, d, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, ErrInvalidY4M
+	}
+	if num, err = strconv.Atoi(n); err != nil {
+		return 0, 0, err
+	}
+	if den, err = strconv.Atoi(d); err != nil {
+		return 0, 0, err
+	}
+	return num, den, nil
+}
+
+// line returns the header line of h, including its newline. yuvj formats
+// are written as their limited range counterpart with XCOLORRANGE=FULL.
+func (h *Y4MHeader) line() (string, error) {
+	pf, rng := h.Format, h.Range
+	if base, ok := y4mFullRange[pf]; ok {
+		if rng == ColorRangeMPEG {
+			return "", fmt.Errorf("%w: %s is full range", ErrInvalidArgument,
+				GetPixFmtName(pf))
+		}
+		pf, rng = base, ColorRangeJPEG
+	}
+	tag, xyscss, err := y4mTag(pf, h.ChromaLocation)
+	if err != nil {
+		return "", err
+	}
+	if h.Width <= 0 || h.Height <= 0 || h.FrameRateNum <= 0 ||
+		h.FrameRateDen <= 0 || h.AspectNum < 0 || h.AspectDen < 0 {
+		return "", fmt.Errorf("%w: bad y4m header %+v", ErrInvalidArgument,
+			*h)
+	}
+	il := h.Interlacing
+	if il == 0 {
+		il = '?'
+	} else if !strings.ContainsRune("ptbm?", rune(il)) {
+		return "", fmt.Errorf("%w: interlacing %q", ErrInvalidArgument, il)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s W%d H%d F%d:%d I%c A%d:%d C%s", y4mMagic, h.Width,
+		h.Height, h.FrameRateNum, h.FrameRateDen, il, h.AspectNum,
+		h.AspectDen, tag)
+	if xyscss != "" {
+		b.WriteString(" XYSCSS=" + xyscss)
+	}
+	switch rng {
+	case ColorRangeMPEG:
+		b.WriteString(" XCOLORRANGE=LIMITED")
+	case ColorRangeJPEG:
+		b.WriteString(" XCOLORRANGE=FULL")
+	}
+	b.WriteByte('\n')
+	return b.String(), nil
+}
+
+// ---------------- Reader ----------------
+
+// Y4MReader reads frames from a YUV4MPEG2 stream.
+type Y4MReader struct {
+	r      *bufio.Reader
+	header Y4MHeader
+	layout rawLayout
+}
+
+// NewY4MReader reads the stream header from r and returns a reader for the
+// frames that follow it.
+//
+// Malformed headers, sizes too large to address and colorspace tags without
+// a pixel format return ErrInvalidY4M.
+func NewY4MReader(r io.Reader) (*Y4MReader, error) {
+	br := bufio.NewReader(r)
+	line, err := readY4MLine(br)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	h, err := parseY4MHeader(line)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := newRawLayout(h.Format, h.Width, h.Height)
+	if err != nil {
+		return nil, err
+	}
+	return &Y4MReader{r: br, header: h, layout: layout}, nil
+}
+
+// readY4MLine reads a header line and strips its newline. Lines must fit in
+// the reader's buffer.
+func readY4MLine(br *bufio.Reader) (string, error) {
+	line, err := br.ReadSlice('\n')
+	switch {
+	case err == bufio.ErrBufferFull:
+		return "", fmt.Errorf("%w: header line too long", ErrInvalidY4M)
+	case err == io.EOF && len(line) > 0:
+		return "", io.ErrUnexpectedEOF
+	case err != nil:
+		return "", err
+	}
+	return string(bytes.TrimSuffix(line, []byte("\n"))), nil
+}
+
+// Header returns the stream header.
+func (r *Y4MReader) Header() Y4MHeader { return r.header }
+
+// FrameSize returns the number of bytes of plane data in every frame.
+func (r *Y4MReader) FrameSize() int { return r.layout.size }
+
+// ReadFrame reads the next frame into a newly allocated Frame whose planes
+// hold the tightly packed plane data. Frame parameters are skipped.
+//
+// At the end of the stream it returns io.EOF, or io.ErrUnexpectedEOF if the
+// stream ends partway through a frame.
+func (r *Y4MReader) ReadFrame() (*Frame, error) {
+	line, err := readY4MLine(r.r)
+	if err != nil {
+		return nil, err
+	}
+	if line != y4mFrameMagic && !strings.HasPrefix(line, y4mFrameMagic+" ") {
+		return nil, fmt.Errorf("%w: missing %s marker", ErrInvalidY4M,
+			y4mFrameMagic)
+	}
+	buf := make([]byte, r.layout.size)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return r.layout.frame(buf), nil
+}
+
+// ---------------- Writer ----------------
+
+// Y4MWriter writes frames to a YUV4MPEG2 stream.
+type Y4MWriter struct {
+	w      io.Writer
+	header Y4MHeader
+	layout rawLayout
+	buf    []byte
+	frames int
+}
+
+// NewY4MWriter writes the stream header h to w and returns a writer for its
+// frames.
+//
+// Formats Y4M cannot express, such as RGB, semi-planar or big-endian ones,
+// and yuv420p chroma locations other than left, center, top left or
+// unspecified return ErrInvalidArgument. The yuvj formats are written as
+// their limited range counterpart marked full range.
+func NewY4MWriter(w io.Writer, h Y4MHeader) (*Y4MWriter, error) {
+	line, err := h.line()
+	if err != nil {
+		return nil, err
+	}
+	layout, err := newRawLayout(h.Format, h.Width, h.Height)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, line); err != nil {
+		return nil, err
+	}
+	return &Y4MWriter{w: w, header: h, layout: layout,
+		buf: make([]byte, len(y4mFrameMagic)+1+layout.size)}, nil
+}
+
+// Frames returns the number of frames written so far.
+func (w *Y4MWriter) Frames() int { return w.frames }
+
+// WriteFrame appends f to the stream, dropping any linesize padding.
+//
+// Frames whose pixel format or size differ from the header return
+// ErrInvalidArgument.
+func (w *Y4MWriter) WriteFrame(f *Frame) error {
+	l := &w.layout
+	if f.format != l.format || f.width != l.width || f.height != l.height {
+		return fmt.Errorf("%w: frame is %s %dx%d, stream is %s %dx%d",
+			ErrInvalidArgument, GetPixFmtName(f.format), f.width, f.height,
+			GetPixFmtName(l.format), l.width, l.height)
+	}
+	n := copy(w.buf, y4mFrameMagic+"\n")
+	l.pack(w.buf[n:], f)
+	if _, err := w.w.Write(w.buf); err != nil {
+		return err
+	}
+	w.frames++
+	return nil
+}
@@ -0,0 +1,152 @@
+package gopixfmts_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+
+	pixfmts "github.com/GreatValueCreamSoda/gopixfmts"
+)
+
+func Test_Y4M_RoundTrip(t *testing.T) {
+	h := pixfmts.Y4MHeader{Format: pixfmts.PixFmtYUV420P, Width: 5, Height: 3,
+		FrameRateNum: 30000, FrameRateDen: 1001, AspectNum: 1, AspectDen: 1,
+		Interlacing: 'p', Range: pixfmts.ColorRangeMPEG,
+		ChromaLocation: pixfmts.ChromaLocationTopLeft}
+	var buf bytes.Buffer
+	w, err := pixfmts.NewY4MWriter(&buf, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const header = "YUV4MPEG2 W5 H3 F30000:1001 Ip A1:1 C420paldv " +
+		"XYSCSS=420PALDV XCOLORRANGE=LIMITED\n"
+	if !strings.HasPrefix(buf.String(), header) {
+		t.Fatalf("unexpected header %q", buf.String())
+	}
+
+	rng := rand.New(rand.NewSource(6))
+	var frames []*pixfmts.Frame
+	for range 2 {
+		f := newFrame(t, pixfmts.PixFmtYUV420P, 5, 3)
+		for _, p := range f.Planes() {
+			rng.Read(p)
+		}
+		if err := w.WriteFrame(f); err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, f)
+	}
+
+	r, err := pixfmts.NewY4MReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Header() != h {
+		t.Fatalf("got header %+v, want %+v", r.Header(), h)
+	}
+	for _, want := range frames {
+		got, err := r.ReadFrame()
+		if err != nil {
+			t.Fatal(err)
+		}
+		equalComponents(t, got, want)
+	}
+	if _, err := r.ReadFrame(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func Test_Y4M_Header(t *testing.T) {
+	r, err := pixfmts.NewY4MReader(strings.NewReader(
+		"YUV4MPEG2 W4 H2 C420p10 XYSCSS=420P10 XCOLORRANGE=FULL\n" +
+			"FRAME Ixyz\n" + strings.Repeat("\x00", 24)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := r.Header()
+	if h.Format != pixfmts.PixFmtYUV420P10LE || h.FrameRateNum != 25 ||
+		h.Interlacing != '?' || h.Colorimetry().Range != pixfmts.ColorRangeJPEG {
+		t.Fatalf("unexpected header %+v", h)
+	}
+	if _, err := r.ReadFrame(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := pixfmts.NewY4MReader(strings.NewReader(
+		"YUV4MPEG2 W4 H2 C420foo\n")); !errors.Is(err, pixfmts.ErrInvalidY4M) {
+		t.Fatalf("expected invalid y4m, got %v", err)
+	}
+	if _, err := pixfmts.NewY4MReader(strings.NewReader(
+		"YUV4MPEG2 W4 H2\nFRAME\n\x00")); err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range []string{"W3000000000 H3000000000",
+		"W65535 H65535"} {
+		_, err := pixfmts.NewY4MReader(strings.NewReader(
+			"YUV4MPEG2 " + size + " C444\nFRAME\n"))
+		if !errors.Is(err, pixfmts.ErrInvalidY4M) {
+			t.Fatalf("%s: expected invalid y4m, got %v", size, err)
+		}
+	}
+}
+
+func Test_Y4M_Formats(t *testing.T) {
+	var buf bytes.Buffer
+	h := pixfmts.Y4MHeader{Format: pixfmts.PixFmtYUVJ422, Width: 2, Height: 2,
+		FrameRateNum: 25, FrameRateDen: 1}
+	if _, err := pixfmts.NewY4MWriter(&buf, h); err != nil {
+		t.Fatal(err)
+	}
+	r, err := pixfmts.NewY4MReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Header(); got.Format != pixfmts.PixFmtYUV422P ||
+		got.Range != pixfmts.ColorRangeJPEG {
+		t.Fatalf("unexpected header %+v", got)
+	}
+
+	// The colorspace tags and XYSCSS values match FFmpeg's yuv4mpegenc.
+	for _, c := range []struct {
+		h    pixfmts.Y4MHeader
+		want string
+	}{
+		{pixfmts.Y4MHeader{Format: pixfmts.PixFmtYUV420P},
+			" C420jpeg XYSCSS=420JPEG\n"},
+		{pixfmts.Y4MHeader{Format: pixfmts.PixFmtYUV420P,
+			ChromaLocation: pixfmts.ChromaLocationTopLeft},
+			" C420paldv XYSCSS=420PALDV\n"},
+		{pixfmts.Y4MHeader{Format: pixfmts.PixFmtYUVA444P},
+			" C444alpha XYSCSS=444\n"},
+		{pixfmts.Y4MHeader{Format: pixfmts.PixFmtYUV422P10LE},
+			" C422p10 XYSCSS=422P10\n"},
+		{pixfmts.Y4MHeader{Format: pixfmts.PixFmtGRAY10LE}, " Cmono10\n"},
+	} {
+		c.h.Width, c.h.Height, c.h.FrameRateNum, c.h.FrameRateDen = 2, 2, 25, 1
+		buf.Reset()
+		if _, err := pixfmts.NewY4MWriter(&buf, c.h); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(buf.String(), c.want) {
+			t.Fatalf("%v: header %q lacks %q", c.h.Format, buf.String(),
+				c.want)
+		}
+	}
+
+	for _, h := range []pixfmts.Y4MHeader{
+		{Format: pixfmts.PixFmtRGB24},
+		{Format: pixfmts.PixFmtNV12},
+		{Format: pixfmts.PixFmtYUV420P10BE},
+		{Format: pixfmts.PixFmtYUV420P,
+			ChromaLocation: pixfmts.ChromaLocationBottom},
+	} {
+		h.Width, h.Height, h.FrameRateNum, h.FrameRateDen = 2, 2, 25, 1
+		if _, err := pixfmts.NewY4MWriter(io.Discard, h); !errors.Is(err,
+			pixfmts.ErrInvalidArgument) {
+			t.Fatalf("%v: expected invalid argument, got %v", h.Format, err)
+		}
+	}
+}